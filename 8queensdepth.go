@@ -3,14 +3,21 @@
 // Revision Date: 3 Sep 2016
 // Implements DepthFirstSearch of hduplooy/gosearch on the 8 queens problem
 // Place 8 queens on a normal 8X8 chess board without any one queen able to capture another
+// Some of the queens can be given up front (with -place or -file) and then only the completions of that placement are searched
 package main
 
 import (
+	"bufio"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 
 	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/search"
 )
 
 // The state of the board is represented by a slice of integers with each entry indicating that rank on the board
@@ -25,6 +32,14 @@ func iabs(a int) int {
 	return a
 }
 
+// fixed has for every rank the file of the queen given in the initial placement or -1 if the rank is still open
+var fixed = []int{-1, -1, -1, -1, -1, -1, -1, -1}
+
+// attacks is true if queens on the two squares can capture each other
+func attacks(rank1, file1, rank2, file2 int) bool {
+	return rank1 == rank2 || file1 == file2 || iabs(file1-file2) == iabs(rank1-rank2)
+}
+
 // Descendants return all valid entries on the next rank based on the current position
 func (brd Board) Descendants() []src.SearchF {
 	// Make the slice holding all the descendants
@@ -32,7 +47,18 @@ func (brd Board) Descendants() []src.SearchF {
 	sz := len(brd)
 	// Go through all the files (columns) looking for valid moves
 	for i := 0; i < 8; i++ {
+		// If the rank was given only its file can be used
+		if fixed[sz] >= 0 && fixed[sz] != i {
+			continue
+		}
 		fine := true
+		// It may not be attacked by any of the queens given on the later ranks
+		for j := sz + 1; j < 8; j++ {
+			if fixed[j] >= 0 && attacks(sz, i, j, fixed[j]) {
+				fine = false
+				break
+			}
+		}
 		// For all previous ranks
 		for j := 0; j < sz; j++ {
 			// If it is in the same file (column) or diagonally they are the same then it is not a valid move
@@ -77,13 +103,87 @@ func (brd Board) Key() string {
 	return strings.Join(tmp, "")
 }
 
+// readPlacement reads the initial placement as 8 rows of 8 characters, a Q is a queen and a . an empty square
+// Empty lines are skipped
+// It returns for each rank the file of its queen or -1 if there is none
+func readPlacement(r io.Reader) ([]int, error) {
+	tmp := make([]int, 0, 8)
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		line := strings.TrimSpace(scan.Text())
+		if line == "" {
+			continue
+		}
+		if len(tmp) == 8 {
+			return nil, errors.New("more than 8 rows given")
+		}
+		if len(line) != 8 {
+			return nil, fmt.Errorf("row %d must have 8 squares: %q", len(tmp)+1, line)
+		}
+		file := -1
+		for i, ch := range line {
+			switch ch {
+			case '.':
+			case 'Q', 'q':
+				if file >= 0 {
+					return nil, fmt.Errorf("row %d has more than one queen", len(tmp)+1)
+				}
+				file = i
+			default:
+				return nil, fmt.Errorf("row %d has an invalid square %q", len(tmp)+1, ch)
+			}
+		}
+		tmp = append(tmp, file)
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+	if len(tmp) != 8 {
+		return nil, fmt.Errorf("expected 8 rows but got %d", len(tmp))
+	}
+	// None of the given queens may be able to capture another one
+	for i := 0; i < 8; i++ {
+		for j := i + 1; j < 8; j++ {
+			if tmp[i] >= 0 && tmp[j] >= 0 && attacks(i, tmp[i], j, tmp[j]) {
+				return nil, fmt.Errorf("the queens on rows %d and %d attack each other", i+1, j+1)
+			}
+		}
+	}
+	return tmp, nil
+}
+
 func main() {
-	// Call DepthFirstSearch with an empty state and we don't want history
-	cnt, ans, _ := src.DepthFirstSearch(Board(make([]int, 0, 8)), false)
-	fmt.Printf("Done in %d steps\n", cnt)
+	place := flag.String("place", "", "initial placement with the rows separated by /, for example .Q....../......../...")
+	file := flag.String("file", "", "text file with the initial placement, one row per line")
+	flag.Parse()
+
+	// Get the initial placement if there is one
+	var err error
+	switch {
+	case *place != "":
+		fixed, err = readPlacement(strings.NewReader(strings.Replace(*place, "/", "\n", -1)))
+	case *file != "":
+		var fl *os.File
+		if fl, err = os.Open(*file); err == nil {
+			fixed, err = readPlacement(fl)
+			fl.Close()
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid placement: %v\n", err)
+		os.Exit(2)
+	}
+
+	// Call DepthFirstSearch with an empty state
+	res := search.DepthFirst(Board(make([]int, 0, 8)))
+	fmt.Println(res)
+	if res.Goal == nil {
+		fmt.Println("No completion exists")
+		os.Exit(1)
+	}
 	// Print the resulting board
 	fmt.Println("+-+-+-+-+-+-+-+-+")
-	brd := ans.(Board)
+	brd := res.Goal.(Board)
 	for _, val := range brd {
 		fmt.Print("|")
 		for i := 0; i < 8; i++ {
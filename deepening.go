@@ -0,0 +1,157 @@
+// deepening.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Compares the iterative deepening searches of the search package to the searches that keep the whole frontier
+// IDAStar solves sliding tile puzzles like A* does in slidingtile.go and IterativeDeepening finds the fewest moves
+// like breadth first search, both only keeping the path to the state being expanded
+// On N queens IterativeDeepening and IDAStar are compared to depth first search as in queensall.go
+// The searches they are compared to are those of the search package with their own loop (BestCostAwayContext,
+// BreadthFirstContext and DepthFirstContext), which keep the closed states
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/queens"
+	"github.com/hduplooy/gosearch-test/search"
+	"github.com/hduplooy/gosearch-test/tiles"
+)
+
+// mostBreadth is the longest 8-puzzle solution searched for without a heuristic
+const mostBreadth = 20
+
+// row prints a line of the comparison
+func row(what, name string, res *search.Result, moves int) {
+	fmt.Printf("%-40s %-10s %6d %10d %8d %5d %14v\n", what, name, moves, res.Expanded, res.PeakFrontier+res.PeakClosed, res.Iterations, res.Time)
+}
+
+// header prints the header of the comparison
+func header() {
+	fmt.Printf("%-40s %-10s %6s %10s %8s %5s %14s\n", "Puzzle", "Search", "Moves", "Steps", "Kept", "Iter", "Time")
+}
+
+// solveTiles solves the puzzle with A* and IDA* (and BFS and iterative deepening if it is a short 8-puzzle)
+// It returns false if they do not all find the same number of moves
+func solveTiles(text string, h tiles.Heuristic) bool {
+	tls, err := tiles.Parse(text)
+	if err != nil {
+		fmt.Println(text, err)
+		return false
+	}
+	n := 0
+	for n*n < len(tls) {
+		n++
+	}
+	game, err := tiles.NewGame(n, tiles.DefaultGoal(n), h)
+	if err != nil {
+		fmt.Println(text, err)
+		return false
+	}
+	start, err := game.Start(tls)
+	if err != nil {
+		fmt.Println(text, err)
+		return false
+	}
+	finds := []struct {
+		name string
+		find func(context.Context, src.SearchF, search.Limits) (*search.Result, error)
+		h    bool
+	}{
+		{"A*", search.BestCostAwayContext, true},
+		{"IDA*", search.IDAStarContext, true},
+		{"BFS", search.BreadthFirstContext, false},
+		{"IDDFS", search.IterativeDeepeningContext, false},
+	}
+	fine := true
+	first := -1
+	for _, val := range finds {
+		if !val.h && (n > 3 || first > mostBreadth) {
+			continue
+		}
+		res, _ := val.find(context.Background(), start, search.Limits{})
+		moves := -1
+		if res.Goal != nil {
+			moves = int(res.Cost)
+		}
+		if first < 0 {
+			first = moves
+		}
+		row(text, val.name, res, moves)
+		if moves != first {
+			fmt.Printf("Not the same as %s\n", finds[0].name)
+			fine = false
+		}
+	}
+	return fine
+}
+
+// placed checks that the board has all its queens and that none can capture another
+func placed(brd queens.Board) bool {
+	if len(brd.Files) != brd.Size {
+		return false
+	}
+	chk := queens.NewBoard(brd.Size)
+	for _, file := range brd.Files {
+		if !chk.Safe(file) {
+			return false
+		}
+		chk.Files = append(chk.Files, file)
+	}
+	return true
+}
+
+// solveQueens places n queens with DFS, iterative deepening and IDA* and checks that they agree
+func solveQueens(n int) bool {
+	finds := []struct {
+		name string
+		find func(context.Context, src.SearchF, search.Limits) (*search.Result, error)
+	}{
+		{"DFS", search.DepthFirstContext},
+		{"IDDFS", search.IterativeDeepeningContext},
+		{"IDA*", search.IDAStarContext},
+	}
+	fine := true
+	var found []bool
+	for _, val := range finds {
+		res, _ := val.find(context.Background(), queens.NewBoard(n), search.Limits{})
+		depth := -1
+		if res.Goal != nil {
+			depth = len(res.Path) - 1
+			if !placed(res.Goal.(queens.Board)) {
+				fmt.Println("Invalid placement", res.Goal.Key())
+				fine = false
+			}
+		}
+		row(fmt.Sprintf("%d queens", n), val.name, res, depth)
+		found = append(found, res.Goal != nil)
+		if found[0] != found[len(found)-1] {
+			fmt.Printf("Not the same as %s\n", finds[0].name)
+			fine = false
+		}
+	}
+	return fine
+}
+
+func main() {
+	board := flag.String("board", "8 6 7 2 5 4 3 0 1", "tiles row by row with 0 for the blank")
+	hname := flag.String("h", "linear", "heuristic: none, manhattan or linear")
+	n := flag.Int("n", 8, "number of queens")
+	flag.Parse()
+
+	heuristics := map[string]tiles.Heuristic{"none": tiles.None, "manhattan": tiles.Manhattan, "linear": tiles.LinearConflict}
+	h, ok := heuristics[*hname]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown heuristic %q\n", *hname)
+		os.Exit(2)
+	}
+	header()
+	fine := solveTiles(*board, h)
+	fine = solveQueens(*n) && fine
+	if !fine {
+		os.Exit(1)
+	}
+}
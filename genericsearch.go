@@ -0,0 +1,105 @@
+// genericsearch.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Compares the searches of the search package over SearchF states (DepthFirstContext and BestCostAwayContext, whose
+// loop the typed searches follow) with those of the typed package, which uses Go generics for the state and key types
+// Every problem is searched three ways: through SearchF (searchf), with a typed Search made by Adapt from the same
+// SearchF state (adapt) and with a typed Search written for it with comparable keys and an Expand that does not
+// allocate (typed). The time, allocations and bytes per step are measured with runtime.MemStats
+// The problems are placing -n queens with depth first search and A* drives across a -rows by -rows Grid network
+// With -verify the searches are checked to take the same steps and find the same cost
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/hduplooy/gosearch-test/queens"
+	"github.com/hduplooy/gosearch-test/roads"
+	"github.com/hduplooy/gosearch-test/search"
+	"github.com/hduplooy/gosearch-test/typed"
+)
+
+// measure runs fn and prints its time, allocations and bytes per step
+func measure(problem, name string, fn func() typed.Outcome) typed.Outcome {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	tm := time.Now()
+	out := fn()
+	dur := time.Since(tm)
+	runtime.ReadMemStats(&after)
+	steps := float64(out.Expanded)
+	fmt.Printf("%-28s %-8s %10.2f %9d %12v %9.0f %11.2f %11.1f\n", problem, name, out.Cost, out.Expanded, dur.Round(time.Microsecond),
+		float64(dur.Nanoseconds())/steps, float64(after.Mallocs-before.Mallocs)/steps, float64(after.TotalAlloc-before.TotalAlloc)/steps)
+	return out
+}
+
+// same checks that the outcomes are the same as the first one
+func same(problem string, outs []typed.Outcome) bool {
+	for _, out := range outs[1:] {
+		if !out.Same(outs[0]) {
+			fmt.Printf("%s: the searches do not agree\n", problem)
+			return false
+		}
+	}
+	return true
+}
+
+func main() {
+	n := flag.Int("n", 26, "number of queens")
+	rows := flag.Int("rows", 200, "the rows (and columns) of the road network")
+	check := flag.Bool("verify", false, "exit with an error if the searches do not take the same steps and find the same cost")
+	flag.Parse()
+
+	fmt.Printf("%-28s %-8s %10s %9s %12s %9s %11s %11s\n", "Problem", "Search", "Cost", "Steps", "Time", "ns/step", "allocs/step", "bytes/step")
+	fine := true
+
+	problem := fmt.Sprintf("%d queens", *n)
+	start := queens.NewBitBoard(*n)
+	fine = same(problem, []typed.Outcome{
+		measure(problem, "searchf", func() typed.Outcome {
+			res, _ := search.DepthFirstContext(context.Background(), start, search.Limits{})
+			return typed.OutcomeOf(res)
+		}),
+		measure(problem, "adapt", func() typed.Outcome {
+			res := typed.Adapt[queens.BitBoard]().DepthFirst(start)
+			return res.Outcome()
+		}),
+		measure(problem, "typed", func() typed.Outcome {
+			res := typed.Queens.DepthFirst(start)
+			return res.Outcome()
+		}),
+	}) && fine
+
+	net := roads.Grid(*rows, *rows, 1)
+	from, to := "R0C0", fmt.Sprintf("R%dC%d", *rows-1, *rows-1)
+	problem = fmt.Sprintf("%dx%d %s to %s", *rows, *rows, from, to)
+	begin, err := net.StartDrive(from, to)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	fine = same(problem, []typed.Outcome{
+		measure(problem, "searchf", func() typed.Outcome {
+			res, _ := search.BestCostAwayContext(context.Background(), begin, search.Limits{})
+			return typed.OutcomeOf(res)
+		}),
+		measure(problem, "adapt", func() typed.Outcome {
+			res := typed.Adapt[*roads.Drive]().BestCostAway(begin)
+			return res.Outcome()
+		}),
+		measure(problem, "typed", func() typed.Outcome {
+			res := typed.Drives(begin.Destination).BestCostAway(typed.Drive{City: begin.City})
+			return res.Outcome()
+		}),
+	}) && fine
+
+	if *check && !fine {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,193 @@
+// grid.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Package grid implements path finding on grid maps (like game maps) as states for hduplooy/gosearch
+// Every cell is either a wall or has a weight, moving between two cells costs the average of their weights
+// (times the square root of 2 for a diagonal move)
+// Maps can be loaded from ASCII text, PGM images or the Moving AI benchmark .map format
+package grid
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Wall is the weight of a cell that can't be entered
+const Wall = -1.0
+
+// Map is the grid
+// Width and Height is the size of the map
+// Weights has the weight of every cell (y*Width+x) or Wall
+// Diagonal allows moves to the 8 neighbours instead of the 4 orthogonal ones, a diagonal move may not cut
+// the corner of a wall
+// Goal is the cell we are looking for a path to
+// minWeight is the smallest weight on the map, used so that Away never overestimates
+type Map struct {
+	Width     int
+	Height    int
+	Weights   []float64
+	Diagonal  bool
+	Goal      int
+	minWeight float64
+}
+
+// NewMap returns an open map of the given size where every cell has weight 1
+func NewMap(width, height int) *Map {
+	mp := &Map{Width: width, Height: height, Weights: make([]float64, width*height)}
+	for i := range mp.Weights {
+		mp.Weights[i] = 1
+	}
+	mp.update()
+	return mp
+}
+
+// update finds the smallest weight on the map, it must be called when the weights were changed
+func (mp *Map) update() {
+	mp.minWeight = math.Inf(1)
+	for _, val := range mp.Weights {
+		if val != Wall && val < mp.minWeight {
+			mp.minWeight = val
+		}
+	}
+	if math.IsInf(mp.minWeight, 1) {
+		mp.minWeight = 1
+	}
+}
+
+// Open is true if (x,y) is on the map and not a wall
+func (mp *Map) Open(x, y int) bool {
+	return x >= 0 && x < mp.Width && y >= 0 && y < mp.Height && mp.Weights[y*mp.Width+x] != Wall
+}
+
+// The moves to the 8 neighbours, the first 4 are the orthogonal ones
+var moves = [8][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}, {1, -1}, {1, 1}, {-1, 1}, {-1, -1}}
+
+// Neighbours calls fn for every cell that can be reached from pos in one move with the cost of the move
+func (mp *Map) Neighbours(pos int, fn func(pos int, cost float64)) {
+	x, y := pos%mp.Width, pos/mp.Width
+	cnt := 4
+	if mp.Diagonal {
+		cnt = 8
+	}
+	for i, mv := range moves[:cnt] {
+		x2, y2 := x+mv[0], y+mv[1]
+		if !mp.Open(x2, y2) {
+			continue
+		}
+		pos2 := y2*mp.Width + x2
+		cost := (mp.Weights[pos] + mp.Weights[pos2]) / 2
+		if i >= 4 {
+			// Don't cut corners
+			if !mp.Open(x2, y) || !mp.Open(x, y2) {
+				continue
+			}
+			cost *= math.Sqrt2
+		}
+		fn(pos2, cost)
+	}
+}
+
+// Distance is the estimate of the cost from pos1 to pos2 if every cell has the smallest weight and there are no walls
+// This is Manhattan distance for 4 neighbours and octile distance for 8 neighbours
+func (mp *Map) Distance(pos1, pos2 int) float64 {
+	dx := math.Abs(float64(pos1%mp.Width - pos2%mp.Width))
+	dy := math.Abs(float64(pos1/mp.Width - pos2/mp.Width))
+	if !mp.Diagonal {
+		return (dx + dy) * mp.minWeight
+	}
+	return (math.Max(dx, dy) + (math.Sqrt2-1)*math.Min(dx, dy)) * mp.minWeight
+}
+
+// Start returns the state at (x,y) looking for a path to (gx,gy)
+func (mp *Map) Start(x, y, gx, gy int) *Cell {
+	mp.Goal = gy*mp.Width + gx
+	return &Cell{mp, y*mp.Width + x, 0}
+}
+
+// Cell is the state, the cell we are at
+// Pos is the position (y*Width+x)
+// TotCost is the cost of the path so far
+type Cell struct {
+	*Map
+	Pos     int
+	TotCost float64
+}
+
+// Descendants returns all the neighbouring cells we can move to
+func (cell *Cell) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0, 8)
+	cell.Neighbours(cell.Pos, func(pos int, cost float64) {
+		tmp = append(tmp, &Cell{cell.Map, pos, cell.TotCost + cost})
+	})
+	return tmp
+}
+
+// Done is true when we reach the goal
+func (cell *Cell) Done() bool {
+	return cell.Pos == cell.Goal
+}
+
+// Cost is the cost of the path so far
+func (cell *Cell) Cost() float64 { return cell.TotCost }
+
+// Away is the distance to the goal (see Distance)
+func (cell *Cell) Away() float64 {
+	return cell.Distance(cell.Pos, cell.Goal)
+}
+
+// Key is the position, so a cell is only expanded once
+func (cell *Cell) Key() string {
+	return strconv.Itoa(cell.Pos)
+}
+
+// String returns the coordinates of the cell and the cost so far
+func (cell *Cell) String() string {
+	return "(" + strconv.Itoa(cell.Pos%cell.Width) + "," + strconv.Itoa(cell.Pos/cell.Width) + ") " + strconv.FormatFloat(cell.TotCost, 'f', 2, 64)
+}
+
+// Path returns the positions from the start to the goal given the history and answer of a search
+func Path(hist []src.SearchF, ans src.SearchF) []int {
+	tmp := make([]int, 0, len(hist)+1)
+	for i := len(hist) - 1; i >= 0; i-- {
+		tmp = append(tmp, hist[i].(*Cell).Pos)
+	}
+	return append(tmp, ans.(*Cell).Pos)
+}
+
+// Draw draws the map with the path on it
+// A wall is #, an open cell with weight 1 is . and other weights are shown rounded as 2 to 9
+// The path is shown with * and the start and end with S and E
+func (mp *Map) Draw(path []int) string {
+	tmp := make([]byte, mp.Width*mp.Height)
+	for i, val := range mp.Weights {
+		switch {
+		case val == Wall:
+			tmp[i] = '#'
+		case val < 1.5:
+			tmp[i] = '.'
+		case val >= 9:
+			tmp[i] = '9'
+		default:
+			tmp[i] = byte('0' + int(val+0.5))
+		}
+	}
+	for i, val := range path {
+		switch i {
+		case 0:
+			tmp[val] = 'S'
+		case len(path) - 1:
+			tmp[val] = 'E'
+		default:
+			tmp[val] = '*'
+		}
+	}
+	var buf strings.Builder
+	for y := 0; y < mp.Height; y++ {
+		buf.Write(tmp[y*mp.Width : (y+1)*mp.Width])
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
@@ -0,0 +1,191 @@
+// jps.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Jump Point Search for maps where every open cell has the same weight and the 8 neighbours are used
+// Instead of adding every neighbour, a state jumps in a straight line (or diagonally) until it reaches a cell where
+// an optimal path might turn (a jump point) so the many symmetric paths over open areas are never expanded
+// This is the version where diagonal moves may not cut corners (the same rule Neighbours uses)
+package grid
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Uniform is true if every open cell on the map has the same weight
+func (mp *Map) Uniform() bool {
+	for _, val := range mp.Weights {
+		if val != Wall && val != mp.minWeight {
+			return false
+		}
+	}
+	return true
+}
+
+// JumpStart returns the Jump Point Search state at (x,y) looking for a path to (gx,gy)
+// The map must be uniform and use the 8 neighbours
+func (mp *Map) JumpStart(x, y, gx, gy int) (*JumpCell, error) {
+	if !mp.Diagonal || !mp.Uniform() {
+		return nil, errors.New("jump point search needs a uniform map with 8 neighbours")
+	}
+	mp.Goal = gy*mp.Width + gx
+	return &JumpCell{mp, y*mp.Width + x, -1, 0}, nil
+}
+
+// JumpCell is the state for Jump Point Search
+// Pos is the position of the jump point, From the position of the jump point before it (-1 for the start)
+// TotCost is the cost of the path so far
+type JumpCell struct {
+	*Map
+	Pos     int
+	From    int
+	TotCost float64
+}
+
+// sign returns -1, 0 or 1
+func sign(a int) int {
+	switch {
+	case a < 0:
+		return -1
+	case a > 0:
+		return 1
+	}
+	return 0
+}
+
+// canMove is true if a single move from (x,y) in direction (dx,dy) is allowed
+func (mp *Map) canMove(x, y, dx, dy int) bool {
+	if !mp.Open(x+dx, y+dy) {
+		return false
+	}
+	return dx == 0 || dy == 0 || (mp.Open(x+dx, y) && mp.Open(x, y+dy))
+}
+
+// jump moves from (x,y) in direction (dx,dy) until it reaches a jump point, which is returned
+// A cell is a jump point if it is the goal, if going straight there is a forced neighbour (a cell next to it
+// that can only be reached optimally through it because a wall is behind it) or, going diagonally,
+// if a straight jump from it finds a jump point
+// If the way is blocked before that -1 is returned
+func (mp *Map) jump(x, y, dx, dy int) int {
+	for {
+		if !mp.canMove(x, y, dx, dy) {
+			return -1
+		}
+		x, y = x+dx, y+dy
+		pos := y*mp.Width + x
+		if pos == mp.Goal {
+			return pos
+		}
+		switch {
+		case dx != 0 && dy != 0:
+			if mp.jump(x, y, dx, 0) >= 0 || mp.jump(x, y, 0, dy) >= 0 {
+				return pos
+			}
+		case dx != 0:
+			if (mp.Open(x, y+1) && !mp.Open(x-dx, y+1)) || (mp.Open(x, y-1) && !mp.Open(x-dx, y-1)) {
+				return pos
+			}
+		default:
+			if (mp.Open(x+1, y) && !mp.Open(x+1, y-dy)) || (mp.Open(x-1, y) && !mp.Open(x-1, y-dy)) {
+				return pos
+			}
+		}
+	}
+}
+
+// directions returns the directions worth searching from the jump point (the pruned neighbours)
+// From the start all 8 are used, otherwise the direction we came from is continued plus, going straight,
+// the forced neighbours and, going diagonally, the two straight parts of the diagonal
+func (cell *JumpCell) directions() [][2]int {
+	if cell.From < 0 {
+		return moves[:]
+	}
+	x, y := cell.Pos%cell.Width, cell.Pos/cell.Width
+	dx, dy := sign(x-cell.From%cell.Width), sign(y-cell.From/cell.Width)
+	if dx != 0 && dy != 0 {
+		return [][2]int{{dx, dy}, {dx, 0}, {0, dy}}
+	}
+	tmp := [][2]int{{dx, dy}}
+	if dx != 0 {
+		for _, side := range []int{1, -1} {
+			if cell.Open(x, y+side) && !cell.Open(x-dx, y+side) {
+				tmp = append(tmp, [2]int{0, side}, [2]int{dx, side})
+			}
+		}
+	} else {
+		for _, side := range []int{1, -1} {
+			if cell.Open(x+side, y) && !cell.Open(x+side, y-dy) {
+				tmp = append(tmp, [2]int{side, 0}, [2]int{side, dy})
+			}
+		}
+	}
+	return tmp
+}
+
+// Descendants returns the jump points reached from this one
+func (cell *JumpCell) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0, 8)
+	x, y := cell.Pos%cell.Width, cell.Pos/cell.Width
+	for _, dir := range cell.directions() {
+		if pos := cell.jump(x, y, dir[0], dir[1]); pos >= 0 {
+			tmp = append(tmp, &JumpCell{cell.Map, pos, cell.Pos, cell.TotCost + cell.Distance(cell.Pos, pos)})
+		}
+	}
+	return tmp
+}
+
+// Done is true when we reach the goal
+func (cell *JumpCell) Done() bool {
+	return cell.Pos == cell.Goal
+}
+
+// Cost is the cost of the path so far
+func (cell *JumpCell) Cost() float64 { return cell.TotCost }
+
+// Away is the octile distance to the goal
+func (cell *JumpCell) Away() float64 {
+	return cell.Distance(cell.Pos, cell.Goal)
+}
+
+// Key is the position, so a jump point is only expanded once
+func (cell *JumpCell) Key() string {
+	return strconv.Itoa(cell.Pos)
+}
+
+// JumpPath returns all the cells from the start to the goal given the history and answer of a search
+// The cells between the jump points are filled in
+func JumpPath(hist []src.SearchF, ans src.SearchF) []int {
+	cell := ans.(*JumpCell)
+	points := make([]int, 0, len(hist)+1)
+	for i := len(hist) - 1; i >= 0; i-- {
+		points = append(points, hist[i].(*JumpCell).Pos)
+	}
+	points = append(points, cell.Pos)
+	tmp := []int{points[0]}
+	for i := 1; i < len(points); i++ {
+		x, y := points[i-1]%cell.Width, points[i-1]/cell.Width
+		x2, y2 := points[i]%cell.Width, points[i]/cell.Width
+		dx, dy := sign(x2-x), sign(y2-y)
+		for x != x2 || y != y2 {
+			x, y = x+dx, y+dy
+			tmp = append(tmp, y*cell.Width+x)
+		}
+	}
+	return tmp
+}
+
+// PathCost returns the cost of a path of cells
+func (mp *Map) PathCost(path []int) float64 {
+	tot := 0.0
+	for i := 1; i < len(path); i++ {
+		cost := (mp.Weights[path[i-1]] + mp.Weights[path[i]]) / 2
+		if path[i-1]%mp.Width != path[i]%mp.Width && path[i-1]/mp.Width != path[i]/mp.Width {
+			cost *= math.Sqrt2
+		}
+		tot += cost
+	}
+	return tot
+}
@@ -0,0 +1,62 @@
+// jps_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks that Jump Point Search finds paths of the same cost as A* on seeded random maps
+package grid_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/grid"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+func TestJumpSameAsAStar(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for m := 0; m < 5; m++ {
+		mp := grid.Random(64, 64, 0.2+0.05*float64(m), rnd)
+		for p := 0; p < 20; p++ {
+			x, y := mp.RandomOpen(rnd)
+			gx, gy := mp.RandomOpen(rnd)
+			want, _ := search.BestCostAwayContext(context.Background(), mp.Start(x, y, gx, gy), search.Limits{})
+			start, err := mp.JumpStart(x, y, gx, gy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _ := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+			if (got.Goal == nil) != (want.Goal == nil) {
+				t.Errorf("map %d from (%d,%d) to (%d,%d): JPS found %v, A* found %v", m, x, y, gx, gy, got.Goal != nil, want.Goal != nil)
+				continue
+			}
+			if got.Goal == nil {
+				continue
+			}
+			// The cells filled in between the jump points must be a path of the same cost
+			path := grid.JumpPath(got.History(), got.Goal)
+			if math.Abs(got.Cost-want.Cost) > 1e-6 || math.Abs(mp.PathCost(path)-want.Cost) > 1e-6 {
+				t.Errorf("map %d from (%d,%d) to (%d,%d): JPS cost %g (path %g), A* cost %g", m, x, y, gx, gy, got.Cost, mp.PathCost(path), want.Cost)
+			}
+			if path[0] != y*mp.Width+x || path[len(path)-1] != gy*mp.Width+gx {
+				t.Errorf("map %d from (%d,%d) to (%d,%d): path goes from %d to %d", m, x, y, gx, gy, path[0], path[len(path)-1])
+			}
+		}
+	}
+}
+
+func TestJumpStartNeedsUniform(t *testing.T) {
+	mp := grid.NewMap(4, 4)
+	if _, err := mp.JumpStart(0, 0, 3, 3); err == nil {
+		t.Error("JPS started on a map with 4 neighbours")
+	}
+	mp.Diagonal = true
+	if _, err := mp.JumpStart(0, 0, 3, 3); err != nil {
+		t.Error(err)
+	}
+	mp.Weights[5] = 2
+	if _, err := mp.JumpStart(0, 0, 3, 3); err == nil {
+		t.Error("JPS started on a map with different weights")
+	}
+}
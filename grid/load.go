@@ -0,0 +1,237 @@
+// load.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Loading maps from ASCII text, PGM images and the Moving AI benchmark formats
+package grid
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// Load reads a map, the format is determined from the start of the data
+// PGM images start with P2 or P5, Moving AI maps with type and everything else is read as ASCII
+func Load(r io.Reader) (*Map, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(data, []byte("P2")) || bytes.HasPrefix(data, []byte("P5")):
+		return loadPGM(data)
+	case bytes.HasPrefix(data, []byte("type")):
+		return loadMovingAI(data)
+	}
+	return loadASCII(strings.Split(strings.TrimRight(string(data), "\r\n"), "\n"))
+}
+
+// weight returns the weight of an ASCII map character
+// . G and S (the Moving AI ground and swamp) have weight 1, 1 to 9 have that weight and
+// # @ O T and W (out of bounds, trees and water in Moving AI maps) are walls
+func weight(ch byte) (float64, error) {
+	switch {
+	case ch == '.' || ch == 'G' || ch == 'S' || ch == ' ':
+		return 1, nil
+	case ch >= '1' && ch <= '9':
+		return float64(ch - '0'), nil
+	case strings.IndexByte("#@OTW", ch) >= 0:
+		return Wall, nil
+	}
+	return 0, fmt.Errorf("invalid map character %q", ch)
+}
+
+// loadASCII reads a map with a line per row and a character per cell (see weight)
+// Short lines are padded with walls
+func loadASCII(lines []string) (*Map, error) {
+	width := 0
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, "\r")
+		if len(lines[i]) > width {
+			width = len(lines[i])
+		}
+	}
+	if width == 0 {
+		return nil, errors.New("the map is empty")
+	}
+	mp := &Map{Width: width, Height: len(lines), Weights: make([]float64, width*len(lines))}
+	for y, line := range lines {
+		for x := 0; x < width; x++ {
+			val := Wall
+			if x < len(line) {
+				var err error
+				if val, err = weight(line[x]); err != nil {
+					return nil, fmt.Errorf("line %d: %v", y+1, err)
+				}
+			}
+			mp.Weights[y*width+x] = val
+		}
+	}
+	mp.update()
+	return mp, nil
+}
+
+// loadMovingAI reads a map in the Moving AI format, a header with type, height and width followed by map and the rows
+func loadMovingAI(data []byte) (*Map, error) {
+	scan := bufio.NewScanner(bytes.NewReader(data))
+	scan.Buffer(make([]byte, 64*1024), 1024*1024)
+	width, height := 0, 0
+	for scan.Scan() {
+		fields := strings.Fields(scan.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "map" {
+			break
+		}
+		if len(fields) == 2 {
+			num, _ := strconv.Atoi(fields[1])
+			switch fields[0] {
+			case "height":
+				height = num
+			case "width":
+				width = num
+			}
+		}
+	}
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("the map has no valid width and height")
+	}
+	var lines []string
+	for len(lines) < height && scan.Scan() {
+		lines = append(lines, scan.Text())
+	}
+	if len(lines) != height {
+		return nil, fmt.Errorf("expected %d rows but got %d", height, len(lines))
+	}
+	return loadASCII(lines)
+}
+
+// pgmTokens splits the header of a PGM image into its tokens skipping comments
+// It returns the tokens and the rest of the data after the whitespace following the last token
+func pgmTokens(data []byte, cnt int) ([]string, []byte) {
+	var tmp []string
+	i := 0
+	for len(tmp) < cnt && i < len(data) {
+		switch ch := data[i]; {
+		case ch == '#':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		default:
+			start := i
+			for i < len(data) && !strings.ContainsRune(" \t\r\n", rune(data[i])) {
+				i++
+			}
+			tmp = append(tmp, string(data[start:i]))
+		}
+	}
+	if i < len(data) {
+		i++
+	}
+	return tmp, data[i:]
+}
+
+// loadPGM reads a grayscale PGM image (ASCII P2 or binary P5)
+// Black (0) is a wall, white has weight 1 and darker grays have higher weights up to 10
+func loadPGM(data []byte) (*Map, error) {
+	head, rest := pgmTokens(data, 4)
+	if len(head) != 4 {
+		return nil, errors.New("invalid PGM header")
+	}
+	width, err1 := strconv.Atoi(head[1])
+	height, err2 := strconv.Atoi(head[2])
+	maxval, err3 := strconv.Atoi(head[3])
+	if err1 != nil || err2 != nil || err3 != nil || width <= 0 || height <= 0 || maxval <= 0 || maxval > 65535 {
+		return nil, errors.New("invalid PGM header")
+	}
+	pixels := make([]int, width*height)
+	if head[0] == "P2" {
+		vals, _ := pgmTokens(rest, len(pixels))
+		if len(vals) != len(pixels) {
+			return nil, errors.New("not enough pixels in the PGM image")
+		}
+		for i, val := range vals {
+			if pixels[i], err1 = strconv.Atoi(val); err1 != nil {
+				return nil, fmt.Errorf("invalid pixel %q", val)
+			}
+		}
+	} else {
+		size := 1
+		if maxval > 255 {
+			size = 2
+		}
+		if len(rest) < len(pixels)*size {
+			return nil, errors.New("not enough pixels in the PGM image")
+		}
+		for i := range pixels {
+			if size == 1 {
+				pixels[i] = int(rest[i])
+			} else {
+				pixels[i] = int(rest[2*i])<<8 | int(rest[2*i+1])
+			}
+		}
+	}
+	mp := &Map{Width: width, Height: height, Weights: make([]float64, len(pixels))}
+	for i, val := range pixels {
+		if val <= 0 {
+			mp.Weights[i] = Wall
+		} else {
+			mp.Weights[i] = 1 + 9*float64(maxval-val)/float64(maxval)
+		}
+	}
+	mp.update()
+	return mp, nil
+}
+
+// Scenario is a path finding problem from a Moving AI .scen file
+// Optimal is the published cost of the optimal path (8 neighbours, diagonal cost sqrt 2, no corner cutting)
+type Scenario struct {
+	Bucket  int
+	Map     string
+	Width   int
+	Height  int
+	StartX  int
+	StartY  int
+	GoalX   int
+	GoalY   int
+	Optimal float64
+}
+
+// LoadScenarios reads a Moving AI .scen file
+func LoadScenarios(r io.Reader) ([]Scenario, error) {
+	var tmp []Scenario
+	scan := bufio.NewScanner(r)
+	for line := 1; scan.Scan(); line++ {
+		fields := strings.Fields(scan.Text())
+		if len(fields) == 0 || fields[0] == "version" {
+			continue
+		}
+		if len(fields) != 9 {
+			return nil, fmt.Errorf("line %d: expected 9 fields", line)
+		}
+		var sc Scenario
+		var err error
+		nums := []*int{&sc.Bucket, nil, &sc.Width, &sc.Height, &sc.StartX, &sc.StartY, &sc.GoalX, &sc.GoalY}
+		for i, ptr := range nums {
+			if ptr != nil {
+				if *ptr, err = strconv.Atoi(fields[i]); err != nil {
+					return nil, fmt.Errorf("line %d: %v", line, err)
+				}
+			}
+		}
+		sc.Map = fields[1]
+		if sc.Optimal, err = strconv.ParseFloat(fields[8], 64); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		tmp = append(tmp, sc)
+	}
+	return tmp, scan.Err()
+}
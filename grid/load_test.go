@@ -0,0 +1,87 @@
+// load_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks loading the ASCII, PGM and Moving AI map formats and the Moving AI scenarios
+package grid_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/grid"
+)
+
+// W is short for a wall in the expected weights
+const W = grid.Wall
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		width   int
+		height  int
+		weights []float64
+	}{
+		{"ascii", "..#\n.9\n", 3, 2, []float64{1, 1, W, 1, 9, W}},
+		{"ascii crlf", "G@\r\nS.\r\n", 2, 2, []float64{1, W, 1, 1}},
+		{"movingai", "type octile\nheight 2\nwidth 3\nmap\n.T.\nW.@\n", 3, 2, []float64{1, W, 1, W, 1, W}},
+		{"pgm ascii", "P2\n# a comment\n3 2\n255\n255 0 255\n0 255 0\n", 3, 2, []float64{1, W, 1, W, 1, W}},
+		{"pgm gray", "P2 2 1 10 10 5", 2, 1, []float64{1, 5.5}},
+		{"pgm binary", "P5\n2 2\n255\n\xff\x00\x00\xff", 2, 2, []float64{1, W, W, 1}},
+		{"pgm 16 bit", "P5 2 1 65535\n\xff\xff\x00\x00", 2, 1, []float64{1, W}},
+	}
+	for _, test := range tests {
+		mp, err := grid.Load(strings.NewReader(test.data))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if mp.Width != test.width || mp.Height != test.height || fmt.Sprint(mp.Weights) != fmt.Sprint(test.weights) {
+			t.Errorf("%s: loaded %dx%d %v, expected %dx%d %v", test.name, mp.Width, mp.Height, mp.Weights, test.width, test.height, test.weights)
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"ascii character", "..x\n"},
+		{"movingai no size", "type octile\nmap\n..\n"},
+		{"movingai rows", "type octile\nheight 3\nwidth 2\nmap\n..\n..\n"},
+		{"movingai character", "type octile\nheight 1\nwidth 2\nmap\n.x\n"},
+		{"pgm header", "P2\n3 2\n"},
+		{"pgm size", "P2\n0 2\n255\n"},
+		{"pgm pixels", "P2\n2 2\n255\n1 2 3\n"},
+		{"pgm pixel", "P2\n2 1\n255\n1 a\n"},
+		{"pgm binary pixels", "P5\n2 2\n255\n\x01\x02"},
+	}
+	for _, test := range tests {
+		if _, err := grid.Load(strings.NewReader(test.data)); err == nil {
+			t.Errorf("%s: loaded without an error", test.name)
+		}
+	}
+}
+
+func TestLoadScenarios(t *testing.T) {
+	data := "version 1\n0\tarena.map\t49\t49\t1\t11\t1\t12\t1\n3\tarena.map\t49\t49\t2\t3\t10\t9\t10.48528137\n"
+	scens, err := grid.LoadScenarios(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []grid.Scenario{
+		{0, "arena.map", 49, 49, 1, 11, 1, 12, 1},
+		{3, "arena.map", 49, 49, 2, 3, 10, 9, 10.48528137},
+	}
+	if fmt.Sprint(scens) != fmt.Sprint(want) {
+		t.Errorf("loaded %v, expected %v", scens, want)
+	}
+	for _, data := range []string{"0 arena.map 49 49 1 11 1 12\n", "0 arena.map 49 x 1 11 1 12 1\n", "0 arena.map 49 49 1 11 1 12 one\n"} {
+		if _, err := grid.LoadScenarios(strings.NewReader(data)); err == nil {
+			t.Errorf("%q loaded without an error", data)
+		}
+	}
+}
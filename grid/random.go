@@ -0,0 +1,41 @@
+// random.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Generating random maps to test and compare the searches on
+package grid
+
+import (
+	"math/rand"
+)
+
+// Random returns an open map of weight 1 with random rectangular walls on about density of the cells
+// The walls are at most a tenth of the map in size and the map uses the 8 neighbours
+func Random(width, height int, density float64, rnd *rand.Rand) *Map {
+	mp := NewMap(width, height)
+	walls := 0
+	for float64(walls) < density*float64(width*height) {
+		w, h := 1+rnd.Intn(width/10+1), 1+rnd.Intn(height/10+1)
+		x, y := rnd.Intn(width), rnd.Intn(height)
+		for yy := y; yy < y+h && yy < height; yy++ {
+			for xx := x; xx < x+w && xx < width; xx++ {
+				if mp.Weights[yy*width+xx] != Wall {
+					mp.Weights[yy*width+xx] = Wall
+					walls++
+				}
+			}
+		}
+	}
+	mp.Diagonal = true
+	mp.update()
+	return mp
+}
+
+// RandomOpen returns a random open cell on the map (the map must have one)
+func (mp *Map) RandomOpen(rnd *rand.Rand) (int, int) {
+	for {
+		x, y := rnd.Intn(mp.Width), rnd.Intn(mp.Height)
+		if mp.Open(x, y) {
+			return x, y
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// gridjps.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Compares Jump Point Search to plain A* (BestCostAwayContext of the search package on grid.Cell) on big random maps
+// For every random start and goal both searches must find a path of the same cost (or both find none)
+// and the number of steps (states expanded) and time taken are reported
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/hduplooy/gosearch-test/grid"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+func main() {
+	size := flag.Int("size", 512, "width and height of the maps")
+	density := flag.Float64("density", 0.2, "part of the cells that are walls")
+	maps := flag.Int("maps", 5, "number of maps")
+	pairs := flag.Int("pairs", 10, "number of start and goal pairs per map")
+	seed := flag.Int64("seed", 1, "random seed")
+	flag.Parse()
+
+	rnd := rand.New(rand.NewSource(*seed))
+	fine := true
+	var astarSteps, jpsSteps int
+	var astarTime, jpsTime time.Duration
+	fmt.Printf("%4s %4s %12s %10s %10s %10s %10s %s\n", "Map", "Pair", "Cost", "A* steps", "A* time", "JPS steps", "JPS time", "")
+	for m := 0; m < *maps; m++ {
+		mp := grid.Random(*size, *size, *density, rnd)
+		for p := 0; p < *pairs; p++ {
+			x, y := mp.RandomOpen(rnd)
+			gx, gy := mp.RandomOpen(rnd)
+
+			res1, _ := search.BestCostAwayContext(context.Background(), mp.Start(x, y, gx, gy), search.Limits{})
+
+			start, err := mp.JumpStart(x, y, gx, gy)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			res2, _ := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+
+			astarSteps += res1.Expanded
+			jpsSteps += res2.Expanded
+			astarTime += res1.Time
+			jpsTime += res2.Time
+			status := "ok"
+			switch {
+			case res1.Goal == nil && res2.Goal == nil:
+				status = "no path"
+			case res1.Goal == nil || res2.Goal == nil:
+				status = "DIFFERENT"
+				fine = false
+			default:
+				// The cells filled in between the jump points must add up to the same cost
+				path := grid.JumpPath(res2.History(), res2.Goal)
+				if math.Abs(res1.Cost-res2.Cost) > 1e-6 || math.Abs(res1.Cost-mp.PathCost(path)) > 1e-6 {
+					status = "DIFFERENT"
+					fine = false
+				}
+			}
+			fmt.Printf("%4d %4d %12.4f %10d %10v %10d %10v %s\n", m+1, p+1, res1.Cost, res1.Expanded, res1.Time.Round(time.Microsecond),
+				res2.Expanded, res2.Time.Round(time.Microsecond), status)
+		}
+	}
+	fmt.Printf("Total A* steps %d in %v, JPS steps %d in %v\n", astarSteps, astarTime.Round(time.Millisecond), jpsSteps, jpsTime.Round(time.Millisecond))
+	if jpsSteps > 0 {
+		fmt.Printf("JPS expands %.1f times fewer states\n", float64(astarSteps)/float64(jpsSteps))
+	}
+	if !fine {
+		os.Exit(1)
+	}
+}
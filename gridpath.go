@@ -0,0 +1,199 @@
+// gridpath.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Implements A* (BestCostAwayContext of the search package) to find the cheapest path on a grid map
+// The map is ASCII text, a PGM image or a Moving AI benchmark .map file and cells can have different weights
+// Away is the octile distance (Manhattan distance with -four) times the smallest weight on the map
+// With -scen the scenarios of a Moving AI .scen file are solved and compared to the published optimal costs
+// With -jps Jump Point Search is used instead, which only works on maps where all the open cells have the same weight
+// On big maps -greedy, -weight or -beam find a path faster that need not be the cheapest
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/grid"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// The map used if none is given
+const defaultMap = `........................................
+..........#######.......................
+................#.......33333...........
+................#.......33333...........
+....#############.......33333...........
+................#.......................
+................#.........#########.....
+................#.........#.............
+..99999.........#.........#.............
+..99999...................#.............
+..99999...................#.............
+..........................#.............`
+
+// loadMap loads the map from a file or returns the default map if name is empty
+func loadMap(name string) (*grid.Map, error) {
+	if name == "" {
+		return grid.Load(strings.NewReader(defaultMap))
+	}
+	fl, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fl.Close()
+	return grid.Load(fl)
+}
+
+// finder is a search of the search package that is used to find the path
+type finder func(context.Context, src.SearchF, search.Limits) (*search.Result, error)
+
+// findPath searches for a path with find and returns the result of the search and the path (nil if there is none)
+func findPath(mp *grid.Map, x, y, gx, gy int, jps bool, find finder) (*search.Result, []int, error) {
+	if jps {
+		start, err := mp.JumpStart(x, y, gx, gy)
+		if err != nil {
+			return nil, nil, err
+		}
+		res, _ := find(context.Background(), start, search.Limits{})
+		if res.Goal == nil {
+			return res, nil, nil
+		}
+		return res, grid.JumpPath(res.History(), res.Goal), nil
+	}
+	res, _ := find(context.Background(), mp.Start(x, y, gx, gy), search.Limits{})
+	if res.Goal == nil {
+		return res, nil, nil
+	}
+	return res, grid.Path(res.History(), res.Goal), nil
+}
+
+// runScenarios solves all the scenarios in the file and compares the costs to the published ones
+// A search that does not find the cheapest path may find a cost up to its bound above the published one, and if
+// it has no bound (optimal is false and Bound is 0) any cost above it
+func runScenarios(name string, most int, four, jps, optimal bool, find finder) bool {
+	fl, err := os.Open(name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	scens, err := grid.LoadScenarios(fl)
+	fl.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	if most > 0 && most < len(scens) {
+		scens = scens[:most]
+	}
+	fine := true
+	maps := make(map[string]*grid.Map)
+	fmt.Printf("%6s %-24s %12s %12s %10s\n", "Bucket", "Map", "Optimal", "Found", "Steps")
+	for _, sc := range scens {
+		mp, ok := maps[sc.Map]
+		if !ok {
+			// The map file is looked for next to the scenario file
+			if mp, err = loadMap(filepath.Join(filepath.Dir(name), filepath.Base(sc.Map))); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return false
+			}
+			mp.Diagonal = !four
+			maps[sc.Map] = mp
+		}
+		res, path, err := findPath(mp, sc.StartX, sc.StartY, sc.GoalX, sc.GoalY, jps, find)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return false
+		}
+		status := "ok"
+		if path == nil {
+			status = "no path"
+			fine = false
+		} else if !four && res.Bound > 0 && res.Cost > res.Bound*sc.Optimal+1e-4 {
+			status = "above bound"
+			fine = false
+		} else if !four && res.Bound > 0 && res.Cost > sc.Optimal+1e-4 {
+			status = "within bound"
+		} else if !four && !optimal && res.Bound == 0 && res.Cost > sc.Optimal+1e-4 {
+			status = "above optimal"
+		} else if !four && math.Abs(res.Cost-sc.Optimal) > 1e-4 {
+			status = "different"
+			fine = false
+		}
+		fmt.Printf("%6d %-24s %12.4f %12.4f %10d %s\n", sc.Bucket, filepath.Base(sc.Map), sc.Optimal, res.Cost, res.Expanded, status)
+	}
+	return fine
+}
+
+func main() {
+	name := flag.String("map", "", "map file (ASCII, PGM or Moving AI .map)")
+	from := flag.String("from", "0,0", "x,y of the start")
+	to := flag.String("to", "39,11", "x,y of the goal")
+	four := flag.Bool("four", false, "only move to the 4 orthogonal neighbours")
+	scen := flag.String("scen", "", "Moving AI .scen file to solve")
+	most := flag.Int("n", 0, "only solve this many scenarios (0 for all)")
+	show := flag.Bool("print", true, "print the map with the path")
+	jps := flag.Bool("jps", false, "use Jump Point Search (uniform maps with 8 neighbours only)")
+	greedy := flag.Bool("greedy", false, "use greedy best first search on Away only")
+	weight := flag.Float64("weight", 1, "use weighted A* counting Away this many times (1 for A*)")
+	width := flag.Int("beam", 0, "use beam search keeping this many states of every depth (0 for A*)")
+	flag.Parse()
+
+	var find finder = search.BestCostAwayContext
+	optimal := true
+	switch {
+	case *greedy:
+		find, optimal = search.GreedyContext, false
+	case *width > 0:
+		optimal = false
+		find = func(ctx context.Context, st src.SearchF, limits search.Limits) (*search.Result, error) {
+			return search.BeamContext(ctx, st, *width, limits)
+		}
+	case *weight != 1:
+		find = func(ctx context.Context, st src.SearchF, limits search.Limits) (*search.Result, error) {
+			return search.WeightedContext(ctx, st, *weight, limits)
+		}
+	}
+
+	if *scen != "" {
+		if !runScenarios(*scen, *most, *four, *jps, optimal, find) {
+			os.Exit(1)
+		}
+		return
+	}
+	mp, err := loadMap(*name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid map:", err)
+		os.Exit(2)
+	}
+	mp.Diagonal = !*four
+	var x, y, gx, gy int
+	_, err1 := fmt.Sscanf(*from, "%d,%d", &x, &y)
+	_, err2 := fmt.Sscanf(*to, "%d,%d", &gx, &gy)
+	if err1 != nil || err2 != nil || !mp.Open(x, y) || !mp.Open(gx, gy) {
+		fmt.Fprintln(os.Stderr, "The start and goal must be open cells on the map")
+		os.Exit(2)
+	}
+	res, path, err := findPath(mp, x, y, gx, gy, *jps, find)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	fmt.Println(res)
+	if path == nil {
+		fmt.Println("No path exists")
+		os.Exit(1)
+	}
+	fmt.Printf("Path of %d cells with cost %.2f\n", len(path), res.Cost)
+	if res.Bound > 0 {
+		fmt.Printf("At most %.2f times the cheapest cost\n", res.Bound)
+	}
+	if *show {
+		fmt.Print(mp.Draw(path))
+	}
+}
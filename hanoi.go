@@ -0,0 +1,46 @@
+// hanoi.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Implements A* (BestCostAwayContext of the search package, which drops positions already expanded) on the
+// Towers of Hanoi of the puzzles package
+// Away is the number of disks not on the target peg yet
+// With 3 pegs the shortest solution has 2^n-1 moves, with 4 pegs it is the Frame-Stewart number
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hduplooy/gosearch-test/puzzles"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// solve searches for the shortest solution and returns the number of steps and the solution
+func solve(disks, pegs int) (*search.Result, []puzzles.Step, error) {
+	start, err := puzzles.NewTowers(disks, pegs, 0, pegs-1)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, _ := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+	if res.Goal == nil {
+		return res, nil, nil
+	}
+	return res, puzzles.Solution(res.History(), res.Goal), nil
+}
+
+func main() {
+	disks := flag.Int("disks", 3, "number of disks")
+	pegs := flag.Int("pegs", 3, "number of pegs")
+	flag.Parse()
+
+	res, steps, err := solve(*disks, *pegs)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid puzzle:", err)
+		os.Exit(2)
+	}
+	fmt.Println(res)
+	fmt.Printf("Solved in %d moves\n", len(steps)-1)
+	fmt.Print(puzzles.Print(steps))
+}
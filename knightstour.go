@@ -0,0 +1,285 @@
+// knightstour.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Implements DepthFirstSearch of hduplooy/gosearch to find a knight's tour
+// The knight must visit every square of the board exactly once, for a closed tour it must also end a move away from where it started
+// The descendants are ordered with Warnsdorff's rule (go to the square with the fewest onward moves first) so that
+// very little backtracking is needed
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// The 8 ways a knight can move
+var knightMoves = [8][2]int{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}}
+
+// Board is the board we are touring
+// Ranks and Files are the size of the board
+// Closed is set if the tour must end a knight's move away from the starting square
+// moves has for every square (rank*Files+file) the squares a knight can move to from it
+type Board struct {
+	Ranks  int
+	Files  int
+	Closed bool
+	moves  [][]int
+}
+
+// NewBoard sets up the knight moves for a board of ranks by files
+func NewBoard(ranks, files int, closed bool) *Board {
+	brd := &Board{Ranks: ranks, Files: files, Closed: closed}
+	brd.moves = make([][]int, ranks*files)
+	for sq := range brd.moves {
+		r, f := sq/files, sq%files
+		for _, mv := range knightMoves {
+			r2, f2 := r+mv[0], f+mv[1]
+			if r2 >= 0 && r2 < ranks && f2 >= 0 && f2 < files {
+				brd.moves[sq] = append(brd.moves[sq], r2*files+f2)
+			}
+		}
+	}
+	return brd
+}
+
+// Tour is our state
+// Path is the squares visited so far in order
+// Visited has an entry for every square indicating if it is on the path
+type Tour struct {
+	*Board
+	Path    []int
+	Visited []bool
+}
+
+// onward returns the number of unvisited squares the knight can move to from sq
+func (tour *Tour) onward(sq int) int {
+	cnt := 0
+	for _, val := range tour.moves[sq] {
+		if !tour.Visited[val] {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// startReachable checks for a closed tour that the starting square can still be reached at the end
+// That is the case if some unvisited square is next to it or if the knight is next to it on the last square
+func (tour *Tour) startReachable() bool {
+	if len(tour.Path) == len(tour.Visited) {
+		return true
+	}
+	for _, val := range tour.moves[tour.Path[0]] {
+		if !tour.Visited[val] {
+			return true
+		}
+	}
+	return false
+}
+
+// hopeless is true if some unvisited square can no longer be reached, or if more than one square can only be
+// entered and not left again (such a square has to be the last one of the tour)
+func (tour *Tour) hopeless() bool {
+	cur := tour.last()
+	ends := 0
+	for sq, val := range tour.Visited {
+		if val {
+			continue
+		}
+		ways := tour.onward(sq)
+		if tour.knightMove(cur, sq) {
+			ways++
+		}
+		if ways == 0 {
+			return true
+		}
+		if ways == 1 {
+			ends++
+		}
+	}
+	return ends > 1
+}
+
+// distance returns the square of the distance between two squares
+func (brd *Board) distance(sq1, sq2 int) int {
+	dr, df := sq1/brd.Files-sq2/brd.Files, sq1%brd.Files-sq2%brd.Files
+	return dr*dr + df*df
+}
+
+// better is true if the knight should rather move to sq1 than to sq2
+// Following Warnsdorff's rule the square with fewer onward moves is better
+// Ties are broken by going to the square furthest from the start for a closed tour (the squares around the start
+// are needed at the end) and otherwise to the square furthest from the centre (the edges are the hardest to reach)
+func (tour *Tour) better(sq1, sq2 int) bool {
+	on1, on2 := tour.onward(sq1), tour.onward(sq2)
+	if on1 != on2 {
+		return on1 < on2
+	}
+	if tour.Closed {
+		return tour.distance(sq1, tour.Path[0]) > tour.distance(sq2, tour.Path[0])
+	}
+	// Measure on a board of double the size so that the centre is on a square
+	dbl := &Board{Ranks: 2 * tour.Ranks, Files: 2 * tour.Files}
+	centre := (tour.Ranks-1)*dbl.Files + tour.Files - 1
+	return dbl.distance(2*(sq1/tour.Files)*dbl.Files+2*(sq1%tour.Files), centre) >
+		dbl.distance(2*(sq2/tour.Files)*dbl.Files+2*(sq2%tour.Files), centre)
+}
+
+// Descendants returns the tour extended with every unvisited square the knight can move to
+// Tours that can no longer be completed are left out
+// They are sorted with the best move according to Warnsdorff's rule last, because DepthFirstSearch expands the last descendant first
+func (tour *Tour) Descendants() []src.SearchF {
+	cur := tour.Path[len(tour.Path)-1]
+	tmp := make([]*Tour, 0, len(tour.moves[cur]))
+	for _, sq := range tour.moves[cur] {
+		if tour.Visited[sq] {
+			continue
+		}
+		path := make([]int, len(tour.Path)+1)
+		copy(path, tour.Path)
+		path[len(tour.Path)] = sq
+		visited := make([]bool, len(tour.Visited))
+		copy(visited, tour.Visited)
+		visited[sq] = true
+		child := &Tour{tour.Board, path, visited}
+		if child.hopeless() || (tour.Closed && !child.startReachable()) {
+			continue
+		}
+		tmp = append(tmp, child)
+	}
+	sort.SliceStable(tmp, func(i, j int) bool {
+		return tour.better(tmp[j].last(), tmp[i].last())
+	})
+	res := make([]src.SearchF, len(tmp))
+	for i, val := range tmp {
+		res[i] = val
+	}
+	return res
+}
+
+// last returns the square the knight is on
+func (tour *Tour) last() int {
+	return tour.Path[len(tour.Path)-1]
+}
+
+// knightMove is true if a knight can move from sq1 to sq2
+func (brd *Board) knightMove(sq1, sq2 int) bool {
+	for _, val := range brd.moves[sq1] {
+		if val == sq2 {
+			return true
+		}
+	}
+	return false
+}
+
+// Done is true if all squares are visited (and for a closed tour the knight can move back to the start)
+func (tour *Tour) Done() bool {
+	if len(tour.Path) != len(tour.Visited) {
+		return false
+	}
+	return !tour.Closed || tour.knightMove(tour.last(), tour.Path[0])
+}
+
+// Cost is not used
+func (tour *Tour) Cost() float64 { return 0.0 }
+
+// Away is not used
+func (tour *Tour) Away() float64 { return 0.0 }
+
+// Key returns the squares visited in order which is unique for each state
+func (tour *Tour) Key() string {
+	tmp := make([]string, len(tour.Path))
+	for i, val := range tour.Path {
+		tmp[i] = strconv.Itoa(val)
+	}
+	return strings.Join(tmp, ",")
+}
+
+// String draws the board with the number of the move on which each square was visited
+func (tour *Tour) String() string {
+	num := make([]int, len(tour.Visited))
+	for i, val := range tour.Path {
+		num[val] = i + 1
+	}
+	width := len(strconv.Itoa(len(num)))
+	line := strings.Repeat("+"+strings.Repeat("-", width), tour.Files) + "+\n"
+	tmp := line
+	for r := 0; r < tour.Ranks; r++ {
+		tmp += "|"
+		for f := 0; f < tour.Files; f++ {
+			if val := num[r*tour.Files+f]; val > 0 {
+				tmp += fmt.Sprintf("%*d|", width, val)
+			} else {
+				tmp += strings.Repeat(" ", width) + "|"
+			}
+		}
+		tmp += "\n" + line
+	}
+	return tmp
+}
+
+// verify checks the tour from scratch: every square is visited once, every step is a knight move and
+// for a closed tour the last square is a knight move away from the first
+func verify(tour *Tour) error {
+	if len(tour.Path) != tour.Ranks*tour.Files {
+		return fmt.Errorf("%d squares visited instead of %d", len(tour.Path), tour.Ranks*tour.Files)
+	}
+	seen := make([]bool, len(tour.Path))
+	for i, sq := range tour.Path {
+		if sq < 0 || sq >= len(seen) || seen[sq] {
+			return fmt.Errorf("square %d on move %d is invalid or visited twice", sq, i+1)
+		}
+		seen[sq] = true
+		if i > 0 && !tour.knightMove(tour.Path[i-1], sq) {
+			return fmt.Errorf("move %d is not a knight move", i+1)
+		}
+	}
+	if tour.Closed && !tour.knightMove(tour.last(), tour.Path[0]) {
+		return fmt.Errorf("the tour does not end a knight move away from the start")
+	}
+	return nil
+}
+
+func main() {
+	ranks := flag.Int("ranks", 8, "number of ranks on the board")
+	files := flag.Int("files", 8, "number of files on the board")
+	closed := flag.Bool("closed", false, "search for a closed tour")
+	rank := flag.Int("rank", 0, "rank of the starting square")
+	file := flag.Int("file", 0, "file of the starting square")
+	flag.Parse()
+
+	if *ranks < 1 || *files < 1 || *rank < 0 || *rank >= *ranks || *file < 0 || *file >= *files {
+		fmt.Fprintln(os.Stderr, "Invalid board size or starting square")
+		os.Exit(2)
+	}
+	// A knight always moves to a square of the other colour, so on a board with an odd number of squares a tour
+	// has to start on the colour with the most squares and it can't be closed
+	if *ranks**files%2 == 1 && (*closed || (*rank+*file)%2 == 1) {
+		fmt.Println("No tour exists")
+		os.Exit(1)
+	}
+	brd := NewBoard(*ranks, *files, *closed)
+	start := *rank**files + *file
+	visited := make([]bool, *ranks**files)
+	visited[start] = true
+	// Call DepthFirstSearch with the knight on the starting square
+	res := search.DepthFirst(&Tour{brd, []int{start}, visited})
+	fmt.Println(res)
+	if res.Goal == nil {
+		fmt.Println("No tour exists")
+		os.Exit(1)
+	}
+	tour := res.Goal.(*Tour)
+	fmt.Print(tour)
+	if err := verify(tour); err != nil {
+		fmt.Println("The tour is not valid:", err)
+		os.Exit(1)
+	}
+	fmt.Println("Tour verified")
+}
@@ -0,0 +1,239 @@
+// ladder.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Package ladder implements word ladders as states for hduplooy/gosearch
+// One word must be changed into another one letter at a time with every step a word in the dictionary
+// (like cold, cord, card, ward, warm). With Edits a step may also insert or delete a letter
+package ladder
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Dictionary holds the words and the index to find the neighbours of a word quickly
+// With Edits a letter may be inserted or deleted as a step, not only changed
+// Goal is the word we are looking for a ladder to
+// buckets has for every pattern with one letter replaced by * (like c*ld) the words that match it,
+// all the words in a bucket are one changed letter apart
+// shorter has for every word with one letter deleted the words it came from, so they are the insertions of it
+// neighbours caches the neighbours of the words already expanded
+type Dictionary struct {
+	Words      []string
+	Edits      bool
+	Goal       int
+	index      map[string]int
+	buckets    map[string][]int
+	shorter    map[string][]int
+	neighbours [][]int
+}
+
+// NewDictionary builds the index for the words (which are made lower case and duplicates removed)
+func NewDictionary(words []string, edits bool) *Dictionary {
+	dict := &Dictionary{Edits: edits, index: make(map[string]int), buckets: make(map[string][]int)}
+	if edits {
+		dict.shorter = make(map[string][]int)
+	}
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" {
+			continue
+		}
+		if _, ok := dict.index[word]; ok {
+			continue
+		}
+		pos := len(dict.Words)
+		dict.Words = append(dict.Words, word)
+		dict.index[word] = pos
+		for i := range word {
+			pattern := word[:i] + "*" + word[i+1:]
+			dict.buckets[pattern] = append(dict.buckets[pattern], pos)
+			if edits {
+				short := word[:i] + word[i+1:]
+				dict.shorter[short] = append(dict.shorter[short], pos)
+			}
+		}
+	}
+	dict.neighbours = make([][]int, len(dict.Words))
+	return dict
+}
+
+// Load reads the dictionary with one word per line, lines starting with # are skipped
+// Only words made of the letters a to z are used (so no names with capitals or words with apostrophes)
+func Load(r io.Reader, edits bool) (*Dictionary, error) {
+	words := make([]string, 0)
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		word := strings.TrimSpace(scan.Text())
+		if word == "" || word[0] == '#' || !plain(word) {
+			continue
+		}
+		words = append(words, word)
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, errors.New("no words in the dictionary")
+	}
+	return NewDictionary(words, edits), nil
+}
+
+// plain is true if the word only has the letters a to z
+func plain(word string) bool {
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Neighbours returns the words one step away from the word at pos
+func (dict *Dictionary) Neighbours(pos int) []int {
+	if dict.neighbours[pos] != nil {
+		return dict.neighbours[pos]
+	}
+	word := dict.Words[pos]
+	seen := map[int]bool{pos: true}
+	tmp := make([]int, 0)
+	add := func(other int) {
+		if !seen[other] {
+			seen[other] = true
+			tmp = append(tmp, other)
+		}
+	}
+	for i := range word {
+		for _, other := range dict.buckets[word[:i]+"*"+word[i+1:]] {
+			add(other)
+		}
+		if dict.Edits {
+			// Deleting a letter
+			if other, ok := dict.index[word[:i]+word[i+1:]]; ok {
+				add(other)
+			}
+		}
+	}
+	if dict.Edits {
+		// Inserting a letter
+		for _, other := range dict.shorter[word] {
+			add(other)
+		}
+	}
+	dict.neighbours[pos] = tmp
+	return tmp
+}
+
+// Start returns the state for the word from looking for a ladder to the word to
+func (dict *Dictionary) Start(from, to string) (*Word, error) {
+	start, ok := dict.index[strings.ToLower(from)]
+	if !ok {
+		return nil, fmt.Errorf("%q is not in the dictionary", from)
+	}
+	goal, ok := dict.index[strings.ToLower(to)]
+	if !ok {
+		return nil, fmt.Errorf("%q is not in the dictionary", to)
+	}
+	if !dict.Edits && len(from) != len(to) {
+		return nil, fmt.Errorf("%q and %q are not of the same length", from, to)
+	}
+	dict.Goal = goal
+	return &Word{dict, start, 0}, nil
+}
+
+// Word is the state with Pos the word in the dictionary and Steps the length of the ladder so far
+type Word struct {
+	*Dictionary
+	Pos   int
+	Steps int
+}
+
+// Descendants returns all the words one step away
+func (wrd *Word) Descendants() []src.SearchF {
+	next := wrd.Neighbours(wrd.Pos)
+	tmp := make([]src.SearchF, len(next))
+	for i, pos := range next {
+		tmp[i] = &Word{wrd.Dictionary, pos, wrd.Steps + 1}
+	}
+	return tmp
+}
+
+// Done is true if we reached the goal word
+func (wrd *Word) Done() bool {
+	return wrd.Pos == wrd.Goal
+}
+
+// Cost is the number of steps
+func (wrd *Word) Cost() float64 { return float64(wrd.Steps) }
+
+// Away is the number of letters that differ from the goal word, every step changes at most one of them
+// With Edits it is the edit distance to the goal word instead, for the same reason
+func (wrd *Word) Away() float64 {
+	if wrd.Edits {
+		return float64(Distance(wrd.Words[wrd.Pos], wrd.Words[wrd.Goal]))
+	}
+	return float64(Mismatches(wrd.Words[wrd.Pos], wrd.Words[wrd.Goal]))
+}
+
+// Key is the word
+func (wrd *Word) Key() string {
+	return wrd.Words[wrd.Pos]
+}
+
+// String is the word
+func (wrd *Word) String() string {
+	return wrd.Words[wrd.Pos]
+}
+
+// Mismatches returns the number of positions where the words of the same length have different letters
+func Mismatches(a, b string) int {
+	cnt := 0
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// Distance returns the edit distance between the words, the least letters changed, inserted or deleted
+// to turn one into the other
+func Distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			best := prev[j-1]
+			if a[i-1] != b[j-1] {
+				best++
+			}
+			if prev[j]+1 < best {
+				best = prev[j] + 1
+			}
+			if cur[j-1]+1 < best {
+				best = cur[j-1] + 1
+			}
+			cur[j] = best
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// Ladder returns the words from the start to the goal given the history and answer of a search
+func Ladder(hist []src.SearchF, ans src.SearchF) []string {
+	tmp := make([]string, 0, len(hist)+1)
+	for i := len(hist) - 1; i >= 0; i-- {
+		tmp = append(tmp, hist[i].(*Word).String())
+	}
+	return append(tmp, ans.(*Word).String())
+}
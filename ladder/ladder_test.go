@@ -0,0 +1,91 @@
+// ladder_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks the length of known ladders over the common words with breadth first search and A*
+package ladder_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/ladder"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// Ladders over the common words with their length in steps (-1 if there is none)
+var instances = []struct {
+	from  string
+	to    string
+	edits bool
+	steps int
+}{
+	{"cold", "warm", false, 4},
+	{"head", "tail", false, 5},
+	{"cat", "dog", false, 3},
+	{"love", "hate", false, 4},
+	{"foot", "ball", false, 5},
+	{"hand", "foot", false, 5},
+	{"wolf", "goat", false, 6},
+	{"zoo", "yak", false, 5},
+	{"ape", "man", false, -1},
+	{"cat", "coat", true, 1},
+	{"sea", "salt", true, 3},
+	{"fly", "wolf", true, 7},
+	{"warm", "cold", true, 4},
+	{"sky", "blue", true, -1},
+}
+
+func TestLadders(t *testing.T) {
+	for _, edits := range []bool{false, true} {
+		dict := ladder.NewDictionary(strings.Fields(ladder.CommonWords), edits)
+		for _, inst := range instances {
+			if inst.edits != edits {
+				continue
+			}
+			for name, find := range map[string]func(context.Context, src.SearchF, search.Limits) (*search.Result, error){
+				"BFS": search.BreadthFirstContext, "A*": search.BestCostAwayContext} {
+				start, err := dict.Start(inst.from, inst.to)
+				if err != nil {
+					t.Fatal(err)
+				}
+				res, _ := find(context.Background(), start, search.Limits{})
+				steps := -1
+				if res.Goal != nil {
+					words := ladder.Ladder(res.History(), res.Goal)
+					steps = len(words) - 1
+					if words[0] != inst.from || words[steps] != inst.to {
+						t.Errorf("%s to %s with %s: the ladder is %v", inst.from, inst.to, name, words)
+					}
+				}
+				if steps != inst.steps {
+					t.Errorf("%s to %s (edits %v) with %s: %d steps, expected %d", inst.from, inst.to, edits, name, steps, inst.steps)
+				}
+			}
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b       string
+		mismatches int
+		distance   int
+	}{
+		{"cold", "warm", 4, 4},
+		{"cat", "cot", 1, 1},
+		{"cat", "coat", 2, 1},
+		{"head", "heal", 1, 1},
+		{"sea", "seat", 0, 1},
+		{"wolf", "wolf", 0, 0},
+	}
+	for _, test := range tests {
+		if got := ladder.Mismatches(test.a, test.b); got != test.mismatches {
+			t.Errorf("Mismatches(%s, %s) = %d, expected %d", test.a, test.b, got, test.mismatches)
+		}
+		if got := ladder.Distance(test.a, test.b); got != test.distance {
+			t.Errorf("Distance(%s, %s) = %d, expected %d", test.a, test.b, got, test.distance)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// words.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// A small list of common words to search ladders over without a dictionary file
+package ladder
+
+// CommonWords is a list of common 3 and 4 letter words separated by white space
+const CommonWords = `ape apt arc are ark arm art ash ate bad bag ban bar bat bay bed bee beg bet bid big bin bit bog bow box boy
+bud bug bun bus but buy cab can cap car cat cob cod cog con cop cot cow cry cub cue cup cut dab dad dig dim
+din dip doe dog don dot dry dub dug dye ear eat eel egg elf elk end era eve ewe eye fan far fat fed fee few
+fig fin fir fit fix fly foe fog for fox fun fur gap gas gel gem get gin god got gum gun gut guy had ham has
+hat hay hen her hid him hip his hit hog hop hot how hub hue hug hum hut ice ill ink inn ion jab jam jar jaw
+jet jig job jog jot joy jug key kid kin kit lab lad lag lap law lay led leg let lid lie lip lit log lot low
+mad man map mat may men met mix mob mop mud mug nap net new nod nor not now nut oak oar oat odd off oil old
+one orb ore our out owl own pad pal pan par pat paw pay pea peg pen pet pie pig pin pit pod pop pot pub pun
+pup put rag ram ran rap rat raw ray red rib rid rig rim rip rob rod rot row rub rug run rut sad sag sap sat
+saw say sea see set sew she shy sin sip sir sit six ski sky sly sob sod son sow soy spy sty sub sum sun tab
+tag tan tap tar tax tea ten the tie tin tip toe ton too top tow toy try tub tug two urn use van vat vet via
+vow wag war was wax way web wed wet who why wig win wit woe wok won yak yam yap yes yet zip zoo able ache acre
+aged aide also arch area army aunt back bake bald ball band bang bank bare bark barn base bath bead beak beam
+bean bear beat been beer bell belt bend bent best bike bile bill bind bird bite blue boar boat body boil bold
+bolt bomb bond bone book boot bore born boss both bowl bull burn bush busy cage cake call calm came camp cane
+card care cart case cash cast cave cell cent chip city clay clip club coal coat code coil coin cold come cone
+cook cool cope copy cord core cork corn cost coup crab crew crop crow cure curl dame damp dare dark dart dash
+date dawn days dead deaf deal dear debt deck deed deep deer dent desk dial dice diet dine dirt dish dive dock
+does dole doll dome done doom door dose dove down drag draw drew drip drop drum duck dull dump dune dusk dust
+duty each earn ease east easy edge else emit even ever evil exit face fact fade fail fair fake fall fame farm
+fast fate fear feed feel feet fell felt file fill film find fine fire firm fish fist five flag flat flaw fled
+flew flip flow foam fold folk fond food fool foot ford fore fork form fort foul four free frog from fuel full
+fund fuse gain gale game gate gave gear gift girl give glad glow glue goal goat gold golf gone good gore gown
+grab gray grew grid grim grin grip grow gulf gust hail hair half hall halt hand hang hard hare harm hate haul
+have head heal heap hear heat heel held hell helm help herb herd here hero hide high hike hill hint hire hold
+hole holy home hood hook hope horn hose host hour huge hull hung hunt hurt idea inch into iron item jail joke
+jump june jury just keen keep kept kick kill kind king kiss kite knee knew knit knot know lace lack lady laid
+lake lamb lame lamp land lane last late lawn lazy lead leaf leak lean leap left lend lens less lick lied life
+lift like limb lime limp line link lion list live load loan lock loft lone long look loop lord lose loss lost
+loud love luck lump lung made mail main make male mall many mare mark mash mask mass mast mate maze meal mean
+meat meet melt mend menu mere mesh mild mile milk mill mind mine mint miss mist moan mode mold mole monk mood
+moon more moss most moth move much mule must mute nail name neat neck need nest news next nice nine none noon
+norm nose note oath obey once only open oven over pace pack page paid pail pain pair pale palm pane park part
+pass past path peak pear peel peer pest pick pier pile pill pine pink pint pipe plan play plea plot plow plug
+plus poem poet pole poll pond pony pool poor pore pork port pose post pour pray prey pull pump pure push race
+rack rage raid rail rain rank rare rate read real rear rely rent rest rice rich ride ring riot rise risk road
+roam roar robe rock rode role roll roof room root rope rose rude ruin rule rush rust safe sage said sail sake
+sale salt same sand sang save scar seal seam seat seed seek seem seen self sell send sent ship shoe shop shot
+show shut sick side sigh sign silk sing sink site size skin slam slap slid slim slip slot slow snap snow soak
+soap sock soft soil sold sole some song soon sort soul soup sour span spin spit spot star stay stem step stir
+stop such suit sung sunk sure swan swim tail take tale talk tall tame tank tape task team tear teal tell tend
+tent term test text than that them then they thin this tide tidy tied tile till time tiny tire toad told toll
+tomb tone took tool tore torn toss tour town trap tray tree trim trip true tube tuck tune turn twin type ugly
+unit upon urge used vain vase vast verb very vest view vine visa void vote wade wage wait wake walk wall wand
+want ward ware warm warn wary wash wasp wave wavy weak wear weed week well went were west what when whip wide
+wife wild will wind wine wing wink wipe wire wise wish with woke wolf wood wool word wore work worm worn wrap
+yard yarn year yell zero zone`
@@ -0,0 +1,84 @@
+// lightsout.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Implements BestCostSearch of hduplooy/gosearch on Lights Out to find the fewest lights to press
+// The lights are given row by row with # for on and . for off (-lights, rows separated by /) or with -random
+// a random grid of size -n that can be solved is used
+// The answer is checked against solving it with linear algebra over GF(2)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hduplooy/gosearch-test/lightsout"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// solve searches for the fewest presses and returns the result of the search and the presses
+// ok is false if the lights can not all be switched off
+func solve(grid *lightsout.Grid, lights uint64) (res *search.Result, presses uint64, ok bool) {
+	res = search.BestCost(grid.Start(lights))
+	if res.Goal == nil {
+		return res, 0, false
+	}
+	return res, res.Goal.(*lightsout.State).Presses, true
+}
+
+// crossCheck compares the search to GF(2) and returns a description of any difference
+func crossCheck(grid *lightsout.Grid, lights, presses uint64, ok bool) string {
+	other, err := grid.Solve(lights)
+	switch {
+	case ok != (err == nil):
+		return "the search and GF(2) do not agree whether it can be solved"
+	case !ok:
+		return ""
+	case grid.Press(lights, presses) != 0 || grid.Press(lights, other) != 0:
+		return "a solution does not switch all the lights off"
+	case bits.OnesCount64(presses) != bits.OnesCount64(other):
+		return fmt.Sprintf("the search presses %d lights but GF(2) only %d", bits.OnesCount64(presses), bits.OnesCount64(other))
+	}
+	return ""
+}
+
+func main() {
+	text := flag.String("lights", "##.##/#.#.#/.###./#.#.#/##.##", "lights row by row with # for on and . for off, rows separated by /")
+	random := flag.Bool("random", false, "use a random grid that can be solved")
+	size := flag.Int("n", 5, "size of the random grid")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed")
+	flag.Parse()
+
+	rnd := rand.New(rand.NewSource(*seed))
+	var grid *lightsout.Grid
+	var lights uint64
+	var err error
+	if *random {
+		if grid, err = lightsout.NewGrid(*size); err == nil {
+			lights = grid.Random(rnd)
+		}
+	} else {
+		grid, lights, err = lightsout.Parse(strings.Replace(*text, "/", "\n", -1))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid grid:", err)
+		os.Exit(2)
+	}
+	fmt.Print(grid.Draw(lights, 0))
+	res, presses, ok := solve(grid, lights)
+	fmt.Println(res)
+	if diff := crossCheck(grid, lights, presses, ok); diff != "" {
+		fmt.Println("GF(2) check failed:", diff)
+		os.Exit(1)
+	}
+	if !ok {
+		fmt.Println("No solution exists (checked with GF(2))")
+		os.Exit(1)
+	}
+	fmt.Printf("Solved with %d presses (the same as GF(2)): %s\n", bits.OnesCount64(presses), strings.Join(grid.Cells(presses), " "))
+	fmt.Print(grid.Draw(lights, presses))
+}
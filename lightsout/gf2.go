@@ -0,0 +1,89 @@
+// gf2.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Solving Lights Out with linear algebra over GF(2) to check the search with
+// Every light gives an equation: the sum (xor) of the presses that toggle it must equal whether it is on
+// Gaussian elimination gives one solution and the presses that change nothing (the null space), all the solutions
+// are found by adding combinations of those and the one with the fewest presses is returned
+package lightsout
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// ErrUnsolvable is returned by Solve if the lights can not all be switched off
+var ErrUnsolvable = errors.New("the lights can not all be switched off")
+
+// Solve returns the fewest lights to press to switch all the lights off, or ErrUnsolvable
+func (grid *Grid) Solve(lights uint64) (uint64, error) {
+	n := grid.Size * grid.Size
+	// Row i is the equation of light i, the bits are the presses that toggle it and rhs is the light
+	// Pressing j toggles i exactly when pressing i toggles j so the rows are the toggles
+	rows := make([]uint64, n)
+	rhs := make([]uint64, n)
+	for i := range rows {
+		rows[i] = grid.toggles[i]
+		rhs[i] = lights >> uint(i) & 1
+	}
+	// pivots has for every column its pivot row or -1 if it is free
+	pivots := make([]int, n)
+	rank := 0
+	for col := 0; col < n; col++ {
+		pivots[col] = -1
+		for r := rank; r < n; r++ {
+			if rows[r]>>uint(col)&1 == 1 {
+				rows[r], rows[rank] = rows[rank], rows[r]
+				rhs[r], rhs[rank] = rhs[rank], rhs[r]
+				break
+			}
+		}
+		if rank == n || rows[rank]>>uint(col)&1 == 0 {
+			continue
+		}
+		for r := 0; r < n; r++ {
+			if r != rank && rows[r]>>uint(col)&1 == 1 {
+				rows[r] ^= rows[rank]
+				rhs[r] ^= rhs[rank]
+			}
+		}
+		pivots[col] = rank
+		rank++
+	}
+	for r := rank; r < n; r++ {
+		if rhs[r] == 1 {
+			return 0, ErrUnsolvable
+		}
+	}
+	// One solution with all the free columns 0
+	var solution uint64
+	for col, r := range pivots {
+		if r >= 0 && rhs[r] == 1 {
+			solution |= 1 << uint(col)
+		}
+	}
+	// A vector of the null space for every free column: press it and the pivots it needs
+	null := make([]uint64, 0)
+	for free, r := range pivots {
+		if r >= 0 {
+			continue
+		}
+		vec := uint64(1) << uint(free)
+		for col, pr := range pivots {
+			if pr >= 0 && rows[pr]>>uint(free)&1 == 1 {
+				vec |= 1 << uint(col)
+			}
+		}
+		null = append(null, vec)
+	}
+	// Try every combination (Gray code order so every step adds one vector)
+	best := solution
+	cur := solution
+	for i := uint64(1); i < 1<<uint(len(null)); i++ {
+		cur ^= null[bits.TrailingZeros64(i)]
+		if bits.OnesCount64(cur) < bits.OnesCount64(best) {
+			best = cur
+		}
+	}
+	return best, nil
+}
@@ -0,0 +1,183 @@
+// lightsout.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Package lightsout implements the Lights Out puzzle as states for hduplooy/gosearch
+// Pressing a light of an NXN grid toggles it and its 4 neighbours, all the lights must be switched off
+// Pressing a light twice undoes it and the order does not matter, so a solution is the set of lights to press
+// The search decides for one light after the other whether to press it. Once the light below a light has been
+// decided nothing can change it anymore, so it must be off by then
+package lightsout
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// MaxSize is the biggest grid supported, the lights are kept as bits of a uint64
+const MaxSize = 8
+
+// Grid holds the size of the puzzle and for every light the lights pressing it toggles
+type Grid struct {
+	Size    int
+	toggles []uint64
+}
+
+// NewGrid sets up a grid of size n
+func NewGrid(n int) (*Grid, error) {
+	if n < 1 || n > MaxSize {
+		return nil, fmt.Errorf("the size must be between 1 and %d", MaxSize)
+	}
+	grid := &Grid{Size: n, toggles: make([]uint64, n*n)}
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			mask := uint64(1) << uint(r*n+c)
+			if r > 0 {
+				mask |= 1 << uint((r-1)*n+c)
+			}
+			if r < n-1 {
+				mask |= 1 << uint((r+1)*n+c)
+			}
+			if c > 0 {
+				mask |= 1 << uint(r*n+c-1)
+			}
+			if c < n-1 {
+				mask |= 1 << uint(r*n+c+1)
+			}
+			grid.toggles[r*n+c] = mask
+		}
+	}
+	return grid, nil
+}
+
+// Press returns the lights after pressing all the lights in presses
+func (grid *Grid) Press(lights, presses uint64) uint64 {
+	for ; presses != 0; presses &= presses - 1 {
+		lights ^= grid.toggles[bits.TrailingZeros64(presses)]
+	}
+	return lights
+}
+
+// Random returns the lights after pressing random lights on a dark grid, so it can always be solved
+func (grid *Grid) Random(rnd *rand.Rand) uint64 {
+	return grid.Press(0, rnd.Uint64()&(1<<uint(grid.Size*grid.Size)-1))
+}
+
+// Parse reads the lights row by row, # (or 1) is on and . (or 0) off, anything else is skipped
+// The size of the grid follows from the number of lights
+func Parse(text string) (*Grid, uint64, error) {
+	cells := make([]bool, 0)
+	for _, ch := range text {
+		switch ch {
+		case '#', '1':
+			cells = append(cells, true)
+		case '.', '0':
+			cells = append(cells, false)
+		}
+	}
+	n := 1
+	for n*n < len(cells) {
+		n++
+	}
+	if len(cells) == 0 || n*n != len(cells) {
+		return nil, 0, fmt.Errorf("%d lights do not make up a square grid", len(cells))
+	}
+	grid, err := NewGrid(n)
+	if err != nil {
+		return nil, 0, err
+	}
+	var lights uint64
+	for i, on := range cells {
+		if on {
+			lights |= 1 << uint(i)
+		}
+	}
+	return grid, lights, nil
+}
+
+// Start returns the start state for the lights
+func (grid *Grid) Start(lights uint64) *State {
+	return &State{grid, lights, 0, 0}
+}
+
+// State is the lights after deciding for the first Next lights whether to press them, Presses has those pressed
+type State struct {
+	*Grid
+	Lights  uint64
+	Presses uint64
+	Next    int
+}
+
+// Descendants decides whether to press the next light
+// The light above it can not change after this, so only the choice that leaves it off is allowed
+func (st *State) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0, 2)
+	if st.Next == st.Size*st.Size {
+		return tmp
+	}
+	for _, press := range []bool{false, true} {
+		lights, presses := st.Lights, st.Presses
+		if press {
+			lights ^= st.toggles[st.Next]
+			presses |= 1 << uint(st.Next)
+		}
+		if above := st.Next - st.Size; above >= 0 && lights>>uint(above)&1 == 1 {
+			continue
+		}
+		tmp = append(tmp, &State{st.Grid, lights, presses, st.Next + 1})
+	}
+	return tmp
+}
+
+// Done is true once every light has been decided and all of them are off
+func (st *State) Done() bool {
+	return st.Next == st.Size*st.Size && st.Lights == 0
+}
+
+// Cost is the number of lights pressed
+func (st *State) Cost() float64 { return float64(bits.OnesCount64(st.Presses)) }
+
+// Away is not used
+func (st *State) Away() float64 { return 0.0 }
+
+// Key is the lights and the next light to decide
+func (st *State) Key() string {
+	return fmt.Sprintf("%x,%d", st.Lights, st.Next)
+}
+
+// Draw returns the lights row by row with # for on and . for off, the lights in presses are marked with an o
+// (or @ if they are on)
+func (grid *Grid) Draw(lights, presses uint64) string {
+	var buf strings.Builder
+	for r := 0; r < grid.Size; r++ {
+		for c := 0; c < grid.Size; c++ {
+			bit := uint(r*grid.Size + c)
+			on, pressed := lights>>bit&1 == 1, presses>>bit&1 == 1
+			switch {
+			case on && pressed:
+				buf.WriteByte('@')
+			case on:
+				buf.WriteByte('#')
+			case pressed:
+				buf.WriteByte('o')
+			default:
+				buf.WriteByte('.')
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+// Cells returns the lights in presses as row,col counted from 1
+func (grid *Grid) Cells(presses uint64) []string {
+	tmp := make([]string, 0)
+	for ; presses != 0; presses &= presses - 1 {
+		bit := bits.TrailingZeros64(presses)
+		tmp = append(tmp, fmt.Sprintf("%d,%d", bit/grid.Size+1, bit%grid.Size+1))
+	}
+	return tmp
+}
@@ -0,0 +1,65 @@
+// lightsout_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Cross-checks the fewest presses found by the search with solving random grids of every size over GF(2)
+package lightsout_test
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/lightsout"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+func TestSameAsGF2(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 1; n <= lightsout.MaxSize; n++ {
+		grid, err := lightsout.NewGrid(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 6; i++ {
+			// Half of them are any lights at all, which can not always be solved
+			lights := grid.Random(rnd)
+			if i%2 == 1 {
+				lights = rnd.Uint64() & (1<<uint(n*n) - 1)
+			}
+			res := search.BestCost(grid.Start(lights))
+			other, err := grid.Solve(lights)
+			if (res.Goal != nil) != (err == nil) {
+				t.Errorf("%dX%d %x: the search found %v and GF(2) gave %v", n, n, lights, res.Goal != nil, err)
+				continue
+			}
+			if res.Goal == nil {
+				if i%2 == 0 {
+					t.Errorf("%dX%d %x: a random grid can not be solved", n, n, lights)
+				}
+				continue
+			}
+			presses := res.Goal.(*lightsout.State).Presses
+			if grid.Press(lights, presses) != 0 || grid.Press(lights, other) != 0 {
+				t.Errorf("%dX%d %x: the search presses %x and GF(2) %x, not both switch all the lights off", n, n, lights, presses, other)
+			}
+			if a, b := bits.OnesCount64(presses), bits.OnesCount64(other); a != b || res.Cost != float64(a) {
+				t.Errorf("%dX%d %x: the search presses %d lights (cost %g) and GF(2) %d", n, n, lights, a, res.Cost, b)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	grid, lights, err := lightsout.Parse("#.#\n...\n..#\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grid.Size != 3 || lights != 1|1<<2|1<<8 {
+		t.Errorf("parsed a %dX%d grid with lights %b", grid.Size, grid.Size, lights)
+	}
+	for _, text := range []string{"", "#.#\n..", "#########/#########/#########/#########/#########/#########/#########/#########/#########"} {
+		if _, _, err := lightsout.Parse(text); err == nil {
+			t.Errorf("%q parsed without an error", text)
+		}
+	}
+}
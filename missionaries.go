@@ -0,0 +1,50 @@
+// missionaries.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Implements breadth first search (BreadthFirstContext of the search package) on the missionaries and cannibals
+// puzzle of the puzzles package, the boat going back and forth reaches the same states many times
+// Everyone must cross the river without the cannibals ever outnumbering the missionaries on either bank or in the boat
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hduplooy/gosearch-test/puzzles"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// solve searches for the shortest solution and returns the number of steps and the solution (nil if there is none)
+func solve(missionaries, cannibals, boat int) (*search.Result, []puzzles.Step, error) {
+	start, err := puzzles.NewRiver(missionaries, cannibals, boat)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, _ := search.BreadthFirstContext(context.Background(), start, search.Limits{})
+	if res.Goal == nil {
+		return res, nil, nil
+	}
+	return res, puzzles.Solution(res.History(), res.Goal), nil
+}
+
+func main() {
+	missionaries := flag.Int("m", 3, "number of missionaries")
+	cannibals := flag.Int("c", 3, "number of cannibals")
+	boat := flag.Int("boat", 2, "number of people the boat holds")
+	flag.Parse()
+
+	res, steps, err := solve(*missionaries, *cannibals, *boat)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid puzzle:", err)
+		os.Exit(2)
+	}
+	fmt.Println(res)
+	if steps == nil {
+		fmt.Println("No solution exists")
+		os.Exit(1)
+	}
+	fmt.Printf("Solved in %d crossings\n", len(steps)-1)
+	fmt.Print(puzzles.Print(steps))
+}
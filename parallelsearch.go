@@ -0,0 +1,148 @@
+// parallelsearch.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Compares parallel A* (ParallelContext of the search package) with A* (BestCostAwayContext) on sliding tile puzzles and
+// on a big generated road network (Grid of the roads package) for different numbers of workers
+// The speedup is the time of A* divided by that of parallel A*, it can only be above 1 with more than one CPU
+// With -verify the costs found with every number of workers are checked to be the same as those of A*
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/roads"
+	"github.com/hduplooy/gosearch-test/search"
+	"github.com/hduplooy/gosearch-test/tiles"
+)
+
+// minMoves is the fewest moves of the puzzles of tiles.Instances compared, shorter ones are solved too quickly
+const minMoves = 24
+
+// problem is a start state to search from
+type problem struct {
+	name  string
+	start src.SearchF
+	cost  float64
+}
+
+// tilePuzzles returns the puzzles of tiles.Instances with at least minMoves moves as problems, solved with linear
+// conflicts
+func tilePuzzles() ([]problem, error) {
+	var tmp []problem
+	for _, inst := range tiles.Instances {
+		if inst.Moves < minMoves {
+			continue
+		}
+		_, start, err := inst.Start(tiles.LinearConflict)
+		if err != nil {
+			return nil, err
+		}
+		tmp = append(tmp, problem{inst.Tiles, start, float64(inst.Moves)})
+	}
+	return tmp, nil
+}
+
+// drives returns drives between corners and across the middle of a grid network of rows by rows cities
+func drives(rows int) ([]problem, error) {
+	net := roads.Grid(rows, rows, 1)
+	name := func(r, c int) string { return fmt.Sprintf("R%dC%d", r, c) }
+	last, mid := rows-1, rows/2
+	pairs := [][2]string{
+		{name(0, 0), name(last, last)},
+		{name(0, last), name(last, 0)},
+		{name(mid, 0), name(mid, last)},
+		{name(0, mid), name(last, mid/2)},
+	}
+	var tmp []problem
+	for _, pair := range pairs {
+		start, err := net.StartDrive(pair[0], pair[1])
+		if err != nil {
+			return nil, err
+		}
+		tmp = append(tmp, problem{fmt.Sprintf("%dx%d %s to %s", rows, rows, pair[0], pair[1]), start, -1})
+	}
+	return tmp, nil
+}
+
+// measure solves the problem with A* and parallel A* with every number of workers and prints a line for each
+// It returns false if a cost is not that of A* (or the one expected)
+func measure(prob problem, workers []int) bool {
+	fine := true
+	base, _ := search.BestCostAwayContext(context.Background(), prob.start, search.Limits{})
+	check := func(name string, res *search.Result) {
+		speedup := float64(base.Time) / float64(res.Time)
+		cost := math.NaN()
+		if res.Goal != nil {
+			cost = res.Cost
+		}
+		fmt.Printf("%-42s %-7s %10.2f %9d %9d %9d %14v %7.2f\n", prob.name, name, cost, res.Expanded, res.Duplicates, res.Messages, res.Time, speedup)
+		if res.Goal == nil || math.Abs(res.Cost-base.Cost) > 1e-6 {
+			fmt.Printf("Not the same cost as A*\n")
+			fine = false
+		} else if prob.cost >= 0 && res.Cost != prob.cost {
+			fmt.Printf("Expected %g\n", prob.cost)
+			fine = false
+		}
+	}
+	check("A*", base)
+	for _, n := range workers {
+		check(fmt.Sprintf("HDA* %d", n), search.Parallel(prob.start, n))
+	}
+	return fine
+}
+
+func main() {
+	domain := flag.String("domain", "both", "tiles, roads or both")
+	counts := flag.String("workers", "1,2,4,8", "the numbers of workers to compare, separated by commas")
+	rows := flag.Int("rows", 200, "the rows (and columns) of the road network")
+	check := flag.Bool("verify", false, "exit with an error if a cost is not the same as that of A*")
+	flag.Parse()
+
+	var workers []int
+	for _, val := range strings.Split(*counts, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(val))
+		if err != nil || n < 1 {
+			fmt.Fprintf(os.Stderr, "Invalid number of workers %q\n", val)
+			os.Exit(2)
+		}
+		workers = append(workers, n)
+	}
+	var probs []problem
+	if *domain == "tiles" || *domain == "both" {
+		tmp, err := tilePuzzles()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		probs = append(probs, tmp...)
+	}
+	if *domain == "roads" || *domain == "both" {
+		tmp, err := drives(*rows)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		probs = append(probs, tmp...)
+	}
+	if len(probs) == 0 {
+		fmt.Fprintf(os.Stderr, "Unknown domain %q\n", *domain)
+		os.Exit(2)
+	}
+	fmt.Printf("%d CPUs, GOMAXPROCS %d\n", runtime.NumCPU(), runtime.GOMAXPROCS(0))
+	fmt.Printf("%-42s %-7s %10s %9s %9s %9s %14s %7s\n", "Problem", "Search", "Cost", "Steps", "Dups", "Messages", "Time", "Speedup")
+	fine := true
+	for _, prob := range probs {
+		fine = measure(prob, workers) && fine
+	}
+	if *check && !fine {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,65 @@
+// pieces.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Implements DepthFirstSearch (or BreadthFirstSearch) of hduplooy/gosearch on piece placement puzzles
+// Place k queens, rooks, bishops, knights or amazons on a board so that none can capture another
+// With -dominate the pieces must also attack every empty square (independent domination)
+// and if -k is 0 the least number of pieces needed for that is searched for
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/pieces"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// solve searches for a placement with the chosen search
+func solve(start src.SearchF, breadth bool) *search.Result {
+	if breadth {
+		return search.BreadthFirst(start)
+	}
+	return search.DepthFirst(start)
+}
+
+func main() {
+	name := flag.String("piece", "queen", "piece to place: queen, rook, bishop, knight or amazon (superqueen)")
+	ranks := flag.Int("ranks", 8, "number of ranks on the board")
+	files := flag.Int("files", 8, "number of files on the board")
+	k := flag.Int("k", 8, "number of pieces to place (with -dominate 0 searches for the least number needed)")
+	dominate := flag.Bool("dominate", false, "the pieces must also attack every empty square")
+	breadth := flag.Bool("breadth", false, "use BreadthFirstSearch instead of DepthFirstSearch")
+	flag.Parse()
+
+	piece, err := pieces.ParsePiece(*name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid puzzle:", err)
+		os.Exit(2)
+	}
+	if *ranks < 1 || *files < 1 || *k < 0 {
+		fmt.Fprintln(os.Stderr, "Invalid puzzle: ranks and files must be at least 1 and k not negative")
+		os.Exit(2)
+	}
+	// Only one value of k is tried unless we are looking for the least number of pieces dominating the board
+	from, to := *k, *k
+	if *dominate && *k == 0 {
+		from, to = 1, *ranks**files
+	}
+	for i := from; i <= to; i++ {
+		res := solve(pieces.NewPuzzle(piece, *ranks, *files, i, *dominate), *breadth)
+		if from != to {
+			fmt.Printf("%d %ss: ", i, piece)
+		}
+		fmt.Println(res)
+		if res.Goal != nil {
+			fmt.Printf("%d %ss\n", len(res.Goal.(*pieces.Placement).Squares), piece)
+			fmt.Print(res.Goal)
+			return
+		}
+	}
+	fmt.Println("No placement exists")
+	os.Exit(1)
+}
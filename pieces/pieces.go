@@ -0,0 +1,303 @@
+// pieces.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Package pieces generalizes the 8 queens problem to other chess pieces and board sizes for hduplooy/gosearch
+// Either k pieces must be placed so that none can capture another (like the queens)
+// or the pieces must also dominate the board (every empty square is attacked) which is independent domination
+package pieces
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Piece is the kind of piece placed
+type Piece int
+
+// The pieces that can be placed, an Amazon (also called a superqueen) moves like a queen and a knight
+const (
+	Queen Piece = iota
+	Rook
+	Bishop
+	Knight
+	Amazon
+)
+
+// Names and letters used when printing for each of the pieces
+var (
+	names   = []string{"queen", "rook", "bishop", "knight", "amazon"}
+	letters = []string{"Q", "R", "B", "N", "A"}
+)
+
+// String returns the name of the piece
+func (p Piece) String() string {
+	return names[p]
+}
+
+// ParsePiece returns the piece with the given name
+func ParsePiece(name string) (Piece, error) {
+	for i, val := range names {
+		if strings.EqualFold(name, val) {
+			return Piece(i), nil
+		}
+	}
+	if strings.EqualFold(name, "superqueen") {
+		return Amazon, nil
+	}
+	return 0, fmt.Errorf("unknown piece %q", name)
+}
+
+// A simple integer abs function
+func iabs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Attacks is true if piece p standing on (r1,f1) can capture a piece on (r2,f2)
+// Other pieces standing in between are not considered, because when the pieces can't capture each other
+// no piece can stand in between two others on a line
+func (p Piece) Attacks(r1, f1, r2, f2 int) bool {
+	dr, df := iabs(r1-r2), iabs(f1-f2)
+	if dr == 0 && df == 0 {
+		return false
+	}
+	line := dr == 0 || df == 0
+	diag := dr == df
+	knight := dr*df == 2
+	switch p {
+	case Queen:
+		return line || diag
+	case Rook:
+		return line
+	case Bishop:
+		return diag
+	case Knight:
+		return knight
+	case Amazon:
+		return line || diag || knight
+	}
+	return false
+}
+
+// Puzzle describes what must be solved
+// Piece is the kind of piece placed on a board with Ranks ranks and Files files
+// K is the number of pieces to place (with domination it is the most that may be used)
+// Dominate is set if all the empty squares must also be attacked
+// attacks has for every square (rank*Files+file) whether a piece on it attacks each of the other squares
+type Puzzle struct {
+	Piece    Piece
+	Ranks    int
+	Files    int
+	K        int
+	Dominate bool
+	attacks  [][]bool
+}
+
+// NewPuzzle sets up the puzzle and returns the empty board as starting state
+func NewPuzzle(piece Piece, ranks, files, k int, dominate bool) *Placement {
+	pz := &Puzzle{Piece: piece, Ranks: ranks, Files: files, K: k, Dominate: dominate}
+	n := ranks * files
+	pz.attacks = make([][]bool, n)
+	for i := range pz.attacks {
+		pz.attacks[i] = make([]bool, n)
+		for j := range pz.attacks[i] {
+			pz.attacks[i][j] = piece.Attacks(i/files, i%files, j/files, j%files)
+		}
+	}
+	return &Placement{pz, nil}
+}
+
+// Placement is the state
+// Squares are the squares (rank*Files+file) the pieces are placed on in the order they were placed
+type Placement struct {
+	*Puzzle
+	Squares []int
+}
+
+// safe is true if a piece can be placed on square sq without capturing or being captured
+func (pl *Placement) safe(sq int) bool {
+	for _, val := range pl.Squares {
+		if val == sq || pl.attacks[val][sq] {
+			return false
+		}
+	}
+	return true
+}
+
+// covered is true if square sq has a piece on it or is attacked by one
+func (pl *Placement) covered(sq int) bool {
+	for _, val := range pl.Squares {
+		if val == sq || pl.attacks[val][sq] {
+			return true
+		}
+	}
+	return false
+}
+
+// uncovered returns the first square that is not covered or -1 if all of them are
+func (pl *Placement) uncovered() int {
+	for sq := 0; sq < pl.Ranks*pl.Files; sq++ {
+		if !pl.covered(sq) {
+			return sq
+		}
+	}
+	return -1
+}
+
+// lines returns for square sq the rank, file and two diagonals it is on
+// The diagonals are numbered so that the four kinds of lines don't overlap
+func (pz *Puzzle) lines(sq int) [4]int {
+	r, f := sq/pz.Files, sq%pz.Files
+	n := pz.Ranks + pz.Files
+	return [4]int{r, n + f, 2*n + r + f, 4*n + r - f + pz.Files}
+}
+
+// sliding returns which of the lines in lines the piece moves along, on each of those only one piece can be placed
+func (p Piece) sliding() [4]bool {
+	switch p {
+	case Queen, Amazon:
+		return [4]bool{true, true, true, true}
+	case Rook:
+		return [4]bool{true, true, false, false}
+	case Bishop:
+		return [4]bool{false, false, true, true}
+	}
+	return [4]bool{}
+}
+
+// enough checks if the pieces still to be placed can fit on the squares after the last one placed
+// The most that can fit is the number of squares still safe, but for a piece moving along ranks only one
+// can go on every rank and likewise for files and diagonals
+func (pl *Placement) enough() bool {
+	next := 0
+	if len(pl.Squares) > 0 {
+		next = pl.Squares[len(pl.Squares)-1] + 1
+	}
+	slide := pl.Piece.sliding()
+	var counts [4]int
+	var seen [4]map[int]bool
+	for i := range seen {
+		seen[i] = make(map[int]bool)
+	}
+	total := 0
+	for sq := next; sq < pl.Ranks*pl.Files; sq++ {
+		if !pl.safe(sq) {
+			continue
+		}
+		total++
+		for i, val := range pl.lines(sq) {
+			if !seen[i][val] {
+				seen[i][val] = true
+				counts[i]++
+			}
+		}
+	}
+	most := total
+	for i, val := range counts {
+		if slide[i] && val < most {
+			most = val
+		}
+	}
+	return most >= pl.K-len(pl.Squares)
+}
+
+// place returns the state with a piece added on square sq
+func (pl *Placement) place(sq int) *Placement {
+	tmp := make([]int, len(pl.Squares)+1)
+	copy(tmp, pl.Squares)
+	tmp[len(pl.Squares)] = sq
+	return &Placement{pl.Puzzle, tmp}
+}
+
+// Descendants returns the states with one more piece that can't capture any other
+// Without domination the pieces are placed in square order so that every set of squares is only generated once
+// With domination the first square not yet covered must be covered by some piece, so only the squares covering it are tried
+func (pl *Placement) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0)
+	if len(pl.Squares) >= pl.K {
+		return tmp
+	}
+	n := pl.Ranks * pl.Files
+	if pl.Dominate {
+		first := pl.uncovered()
+		if first < 0 {
+			return tmp
+		}
+		for sq := 0; sq < n; sq++ {
+			if (sq == first || pl.attacks[sq][first]) && pl.safe(sq) {
+				tmp = append(tmp, pl.place(sq))
+			}
+		}
+		return tmp
+	}
+	next := 0
+	if len(pl.Squares) > 0 {
+		next = pl.Squares[len(pl.Squares)-1] + 1
+	}
+	for sq := next; sq < n; sq++ {
+		if !pl.safe(sq) {
+			continue
+		}
+		// Only keep it if the rest of the pieces can still fit
+		if child := pl.place(sq); child.enough() {
+			tmp = append(tmp, child)
+		}
+	}
+	return tmp
+}
+
+// Done is true when all K pieces are placed or, for domination, when the board is covered
+func (pl *Placement) Done() bool {
+	if pl.Dominate {
+		return pl.uncovered() < 0
+	}
+	return len(pl.Squares) == pl.K
+}
+
+// Cost is not used
+func (pl *Placement) Cost() float64 { return 0.0 }
+
+// Away is not used
+func (pl *Placement) Away() float64 { return 0.0 }
+
+// Key returns the squares in order, so the same set of squares placed in another order is the same state
+func (pl *Placement) Key() string {
+	occupied := make([]bool, pl.Ranks*pl.Files)
+	for _, val := range pl.Squares {
+		occupied[val] = true
+	}
+	tmp := make([]string, 0, len(pl.Squares))
+	for sq, val := range occupied {
+		if val {
+			tmp = append(tmp, strconv.Itoa(sq))
+		}
+	}
+	return strings.Join(tmp, ",")
+}
+
+// String draws the board with the letter of the piece where they are placed
+func (pl *Placement) String() string {
+	occupied := make([]bool, pl.Ranks*pl.Files)
+	for _, val := range pl.Squares {
+		occupied[val] = true
+	}
+	line := strings.Repeat("+-", pl.Files) + "+\n"
+	tmp := line
+	for r := 0; r < pl.Ranks; r++ {
+		tmp += "|"
+		for f := 0; f < pl.Files; f++ {
+			if occupied[r*pl.Files+f] {
+				tmp += letters[pl.Piece] + "|"
+			} else {
+				tmp += " |"
+			}
+		}
+		tmp += "\n" + line
+	}
+	return tmp
+}
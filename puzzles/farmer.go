@@ -0,0 +1,155 @@
+// farmer.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// The wolf, goat and cabbage puzzle
+// A farmer must take a wolf, a goat and a cabbage across a river in a boat that holds him and one of them
+// Left alone without the farmer the wolf eats the goat and the goat eats the cabbage
+// Other items, what eats what and the size of the boat can be given as well
+package puzzles
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Farmer holds the items to take across, Eats has the pairs where the first item eats the second
+// when the farmer is not there and Boat is the number of items the farmer can take with
+type Farmer struct {
+	Items []string
+	Eats  [][2]int
+	Boat  int
+}
+
+// WolfGoatCabbage returns the start state of the usual puzzle
+func WolfGoatCabbage() *Crossing {
+	st, _ := NewFarmer([]string{"wolf", "goat", "cabbage"}, [][2]int{{0, 1}, {1, 2}}, 1)
+	return st
+}
+
+// NewFarmer returns the start state with the farmer and all the items on the left bank
+func NewFarmer(items []string, eats [][2]int, boat int) (*Crossing, error) {
+	if len(items) < 1 || len(items) > 32 {
+		return nil, errors.New("there must be between 1 and 32 items")
+	}
+	if boat < 0 {
+		return nil, errors.New("invalid boat size")
+	}
+	for _, pair := range eats {
+		if pair[0] < 0 || pair[0] >= len(items) || pair[1] < 0 || pair[1] >= len(items) || pair[0] == pair[1] {
+			return nil, fmt.Errorf("invalid pair %v", pair)
+		}
+	}
+	return &Crossing{&Farmer{items, eats, boat}, 0, false, 0, ""}, nil
+}
+
+// Crossing is the state with the bits of Right set for the items on the right bank
+// There is the bank of the farmer (and boat), Trips the number of crossings and move the last one
+type Crossing struct {
+	*Farmer
+	Right uint32
+	There bool
+	Trips int
+	move  string
+}
+
+// safe is true if nothing gets eaten on the bank where the farmer is not
+func (st *Crossing) safe(right uint32, there bool) bool {
+	for _, pair := range st.Eats {
+		a, b := right>>uint(pair[0])&1 == 1, right>>uint(pair[1])&1 == 1
+		if a == b && a != there {
+			return false
+		}
+	}
+	return true
+}
+
+// Descendants has the farmer cross with every group of up to Boat items from his bank that leaves nothing to be eaten
+func (st *Crossing) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0)
+	// here has the bits of the items on the bank of the farmer
+	all := uint32(1)<<uint(len(st.Items)) - 1
+	here := all &^ st.Right
+	if st.There {
+		here = st.Right
+	}
+	// Every subset of here
+	for sub := here; ; sub = (sub - 1) & here {
+		if count(sub) <= st.Boat {
+			right := st.Right ^ sub
+			if st.safe(right, !st.There) {
+				tmp = append(tmp, &Crossing{st.Farmer, right, !st.There, st.Trips + 1, st.describe(sub)})
+			}
+		}
+		if sub == 0 {
+			break
+		}
+	}
+	return tmp
+}
+
+// count is the number of bits set
+func count(bits uint32) int {
+	cnt := 0
+	for ; bits != 0; bits &= bits - 1 {
+		cnt++
+	}
+	return cnt
+}
+
+// names returns the names of the items in bits
+func (st *Crossing) names(bits uint32) []string {
+	tmp := make([]string, 0)
+	for i, name := range st.Items {
+		if bits>>uint(i)&1 == 1 {
+			tmp = append(tmp, name)
+		}
+	}
+	return tmp
+}
+
+// describe returns the move of the farmer taking the items in sub across
+func (st *Crossing) describe(sub uint32) string {
+	with := "alone"
+	if sub != 0 {
+		with = "with " + strings.Join(st.names(sub), " and ")
+	}
+	side := "right"
+	if st.There {
+		side = "left"
+	}
+	return fmt.Sprintf("farmer %s to the %s", with, side)
+}
+
+// Done is true when everything is on the right bank
+func (st *Crossing) Done() bool {
+	return st.There && count(st.Right) == len(st.Items)
+}
+
+// Cost is the number of crossings
+func (st *Crossing) Cost() float64 { return float64(st.Trips) }
+
+// Away is not used
+func (st *Crossing) Away() float64 { return 0.0 }
+
+// Key is the items on the right bank and the bank of the farmer
+func (st *Crossing) Key() string {
+	return fmt.Sprintf("%d,%t", st.Right, st.There)
+}
+
+// Move is the last crossing
+func (st *Crossing) Move() string { return st.move }
+
+// String lists what is on each bank
+func (st *Crossing) String() string {
+	all := uint32(1)<<uint(len(st.Items)) - 1
+	left, right := st.names(all&^st.Right), st.names(st.Right)
+	if st.There {
+		right = append([]string{"farmer"}, right...)
+	} else {
+		left = append([]string{"farmer"}, left...)
+	}
+	return fmt.Sprintf("%s | %s", strings.Join(left, " "), strings.Join(right, " "))
+}
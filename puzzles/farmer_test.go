@@ -0,0 +1,41 @@
+// farmer_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Solves wolf, goat and cabbage puzzles with known shortest solutions
+package puzzles_test
+
+import (
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/puzzles"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+func TestFarmer(t *testing.T) {
+	wgc := []string{"wolf", "goat", "cabbage"}
+	// Puzzles with the number of crossings of their shortest solutions (-1 if there is none)
+	tests := []struct {
+		items []string
+		eats  [][2]int
+		boat  int
+		trips int
+	}{
+		{wgc, [][2]int{{0, 1}, {1, 2}}, 1, 7},
+		{wgc, [][2]int{{0, 1}, {1, 2}}, 2, 3},
+		{wgc, [][2]int{{0, 1}, {1, 2}}, 0, -1},
+		{wgc, nil, 1, 5},
+		{[]string{"wolf", "goat", "cabbage", "dog"}, [][2]int{{0, 1}, {1, 2}, {3, 0}, {3, 1}}, 1, -1},
+	}
+	for _, test := range tests {
+		start, err := puzzles.NewFarmer(test.items, test.eats, test.boat)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := moves(t, start, search.BreadthFirstContext); got != test.trips {
+			t.Errorf("%v eats %v boat %d: %d crossings, expected %d", test.items, test.eats, test.boat, got, test.trips)
+		}
+	}
+	if got := moves(t, puzzles.WolfGoatCabbage(), search.BreadthFirstContext); got != 7 {
+		t.Errorf("WolfGoatCabbage: %d crossings, expected 7", got)
+	}
+}
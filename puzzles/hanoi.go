@@ -0,0 +1,128 @@
+// hanoi.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// The Towers of Hanoi
+// A stack of disks must be moved from one peg to another, one disk at a time and never onto a smaller disk
+// With 3 pegs it takes 2^n-1 moves, with more pegs it can be done in fewer
+package puzzles
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Towers holds the number of disks and pegs and the peg the disks must be moved to
+type Towers struct {
+	Disks int
+	Pegs  int
+	To    int
+}
+
+// NewTowers returns the start state with all the disks on peg from (counted from 0) that must be moved to peg to
+func NewTowers(disks, pegs, from, to int) (*Hanoi, error) {
+	if disks < 1 || disks > 64 {
+		return nil, errors.New("there must be between 1 and 64 disks")
+	}
+	if pegs < 3 || pegs > 26 {
+		return nil, errors.New("there must be between 3 and 26 pegs")
+	}
+	if from < 0 || from >= pegs || to < 0 || to >= pegs {
+		return nil, errors.New("invalid peg")
+	}
+	on := make([]byte, disks)
+	for i := range on {
+		on[i] = byte(from)
+	}
+	return &Hanoi{&Towers{disks, pegs, to}, on, 0, ""}, nil
+}
+
+// Hanoi is the state with On holding for every disk (the smallest first) the peg it is on
+// Moves is the number of moves made and move the last one
+type Hanoi struct {
+	*Towers
+	On    []byte
+	Moves int
+	move  string
+}
+
+// tops returns for every peg the smallest disk on it or Disks if the peg is empty
+func (st *Hanoi) tops() []int {
+	tmp := make([]int, st.Pegs)
+	for i := range tmp {
+		tmp[i] = st.Disks
+	}
+	for i := len(st.On) - 1; i >= 0; i-- {
+		tmp[st.On[i]] = i
+	}
+	return tmp
+}
+
+// Descendants moves the top disk of every peg onto every peg that is empty or has a bigger disk on top
+func (st *Hanoi) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0)
+	tops := st.tops()
+	for from, disk := range tops {
+		if disk == st.Disks {
+			continue
+		}
+		for to, other := range tops {
+			if to == from || other < disk {
+				continue
+			}
+			on := make([]byte, len(st.On))
+			copy(on, st.On)
+			on[disk] = byte(to)
+			move := fmt.Sprintf("disk %d from %c to %c", disk+1, 'A'+from, 'A'+to)
+			tmp = append(tmp, &Hanoi{st.Towers, on, st.Moves + 1, move})
+		}
+	}
+	return tmp
+}
+
+// Done is true when all the disks are on the target peg
+func (st *Hanoi) Done() bool {
+	for _, val := range st.On {
+		if int(val) != st.To {
+			return false
+		}
+	}
+	return true
+}
+
+// Cost is the number of moves
+func (st *Hanoi) Cost() float64 { return float64(st.Moves) }
+
+// Away is the number of disks not yet on the target peg, each of them must still be moved at least once
+func (st *Hanoi) Away() float64 {
+	cnt := 0
+	for _, val := range st.On {
+		if int(val) != st.To {
+			cnt++
+		}
+	}
+	return float64(cnt)
+}
+
+// Key is the peg of every disk
+func (st *Hanoi) Key() string {
+	return string(st.On)
+}
+
+// Move is the last move
+func (st *Hanoi) Move() string { return st.move }
+
+// String lists the disks on every peg from the bottom up
+func (st *Hanoi) String() string {
+	pegs := make([][]string, st.Pegs)
+	for i := len(st.On) - 1; i >= 0; i-- {
+		pegs[st.On[i]] = append(pegs[st.On[i]], fmt.Sprint(i+1))
+	}
+	tmp := make([]string, st.Pegs)
+	for i, val := range pegs {
+		tmp[i] = fmt.Sprintf("%c[%s]", 'A'+i, strings.Join(val, " "))
+	}
+	return strings.Join(tmp, " ")
+}
@@ -0,0 +1,42 @@
+// hanoi_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Solves the Towers of Hanoi and checks the number of moves against the known values
+package puzzles_test
+
+import (
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/puzzles"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// The number of moves of the shortest solutions for 1 disk and more with 4 and 5 pegs
+var (
+	fourPegs = []int{1, 3, 5, 9, 13, 17, 25, 33}
+	fivePegs = []int{1, 3, 5, 7, 11, 15, 19}
+)
+
+// towers solves the towers with BestCostAwaySearch and checks the number of moves
+func towers(t *testing.T, disks, pegs, expect int) {
+	t.Helper()
+	start, err := puzzles.NewTowers(disks, pegs, 0, pegs-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := moves(t, start, search.BestCostAwayContext); got != expect {
+		t.Errorf("%d disks %d pegs: %d moves, expected %d", disks, pegs, got, expect)
+	}
+}
+
+func TestHanoi(t *testing.T) {
+	for disks := 1; disks <= 10; disks++ {
+		towers(t, disks, 3, 1<<uint(disks)-1)
+	}
+	for i, val := range fourPegs {
+		towers(t, i+1, 4, val)
+	}
+	for i, val := range fivePegs {
+		towers(t, i+1, 5, val)
+	}
+}
@@ -0,0 +1,162 @@
+// jugs.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// The water jugs puzzle
+// There are jugs with the given capacities and no other markings, a jug can be filled from the tap, emptied
+// or poured into another jug until that one is full or it is empty
+// One of the jugs must end up holding exactly the target amount
+package puzzles
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Jugs holds what is shared by all the states
+// Caps is the capacity of every jug and Target the amount that must be measured in any of the jugs
+// If Goal is given all the jugs must end up with exactly those levels instead
+// With NoTap the jugs can not be filled or emptied, only poured into each other (like splitting 8 litres in two)
+type Jugs struct {
+	Caps   []int
+	Target int
+	Goal   []int
+	NoTap  bool
+}
+
+// check makes sure every jug can hold its level
+func (jugs *Jugs) check(levels []int) error {
+	if len(levels) != len(jugs.Caps) {
+		return fmt.Errorf("%d levels given for %d jugs", len(levels), len(jugs.Caps))
+	}
+	for i, val := range levels {
+		if val < 0 || val > jugs.Caps[i] {
+			return fmt.Errorf("jug %d can not hold %d", i+1, val)
+		}
+	}
+	return nil
+}
+
+// Start returns the start state of the puzzle with the jugs holding levels (all empty if levels is nil)
+func (jugs *Jugs) Start(levels []int) (*JugState, error) {
+	if len(jugs.Caps) < 1 {
+		return nil, errors.New("there must be at least one jug")
+	}
+	most := 0
+	for i, val := range jugs.Caps {
+		if val < 1 {
+			return nil, fmt.Errorf("jug %d has an invalid capacity %d", i+1, val)
+		}
+		if val > most {
+			most = val
+		}
+	}
+	if levels == nil {
+		levels = make([]int, len(jugs.Caps))
+	}
+	if err := jugs.check(levels); err != nil {
+		return nil, err
+	}
+	if jugs.Goal != nil {
+		if err := jugs.check(jugs.Goal); err != nil {
+			return nil, fmt.Errorf("invalid goal: %v", err)
+		}
+	} else if jugs.Target < 1 || jugs.Target > most {
+		return nil, fmt.Errorf("the target %d does not fit in any jug", jugs.Target)
+	}
+	return &JugState{Jugs: jugs, Levels: levels}, nil
+}
+
+// JugState is the amount in every jug after Moves moves, move is the last move
+type JugState struct {
+	*Jugs
+	Levels []int
+	Moves  int
+	move   string
+}
+
+// next returns a copy of the state one move further
+func (st *JugState) next(move string) *JugState {
+	tmp := &JugState{st.Jugs, make([]int, len(st.Levels)), st.Moves + 1, move}
+	copy(tmp.Levels, st.Levels)
+	return tmp
+}
+
+// Descendants fills, empties and pours every jug where it changes something
+func (st *JugState) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0)
+	for i, val := range st.Levels {
+		if !st.NoTap && val < st.Caps[i] {
+			nxt := st.next(fmt.Sprintf("fill %d", i+1))
+			nxt.Levels[i] = st.Caps[i]
+			tmp = append(tmp, nxt)
+		}
+		if !st.NoTap && val > 0 {
+			nxt := st.next(fmt.Sprintf("empty %d", i+1))
+			nxt.Levels[i] = 0
+			tmp = append(tmp, nxt)
+		}
+		for j := range st.Levels {
+			if j == i || val == 0 || st.Levels[j] == st.Caps[j] {
+				continue
+			}
+			amount := st.Caps[j] - st.Levels[j]
+			if val < amount {
+				amount = val
+			}
+			nxt := st.next(fmt.Sprintf("pour %d into %d", i+1, j+1))
+			nxt.Levels[i] -= amount
+			nxt.Levels[j] += amount
+			tmp = append(tmp, nxt)
+		}
+	}
+	return tmp
+}
+
+// Done is true if the jugs hold the goal levels or else if any jug holds the target
+func (st *JugState) Done() bool {
+	if st.Goal != nil {
+		for i, val := range st.Levels {
+			if val != st.Goal[i] {
+				return false
+			}
+		}
+		return true
+	}
+	for _, val := range st.Levels {
+		if val == st.Target {
+			return true
+		}
+	}
+	return false
+}
+
+// Cost is the number of moves
+func (st *JugState) Cost() float64 { return float64(st.Moves) }
+
+// Away is not used
+func (st *JugState) Away() float64 { return 0.0 }
+
+// Key is the levels of the jugs
+func (st *JugState) Key() string {
+	tmp := make([]string, len(st.Levels))
+	for i, val := range st.Levels {
+		tmp[i] = strconv.Itoa(val)
+	}
+	return strings.Join(tmp, ",")
+}
+
+// Move is the last move
+func (st *JugState) Move() string { return st.move }
+
+// String shows every jug as level/capacity
+func (st *JugState) String() string {
+	tmp := make([]string, len(st.Levels))
+	for i, val := range st.Levels {
+		tmp[i] = fmt.Sprintf("%d/%d", val, st.Caps[i])
+	}
+	return strings.Join(tmp, " ")
+}
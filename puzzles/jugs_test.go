@@ -0,0 +1,62 @@
+// jugs_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Solves water jugs puzzles with known shortest solutions
+package puzzles_test
+
+import (
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/puzzles"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+func TestJugs(t *testing.T) {
+	// Puzzles with the number of moves of their shortest solutions (-1 if there is none)
+	tests := []struct {
+		caps   []int
+		levels []int
+		target int
+		goal   []int
+		notap  bool
+		moves  int
+	}{
+		{[]int{3, 5}, nil, 4, nil, false, 6},
+		{[]int{4, 3}, nil, 2, nil, false, 4},
+		{[]int{9, 4}, nil, 6, nil, false, 8},
+		{[]int{7, 11}, nil, 6, nil, false, 10},
+		{[]int{3, 5}, nil, 0, []int{0, 4}, false, 7},
+		{[]int{2, 4}, nil, 3, nil, false, -1},
+		{[]int{8, 5, 3}, []int{8, 0, 0}, 0, []int{4, 4, 0}, true, 7},
+		{[]int{12, 8, 5}, []int{12, 0, 0}, 0, []int{6, 6, 0}, true, 7},
+		{[]int{10, 7, 3}, []int{10, 0, 0}, 0, []int{5, 5, 0}, true, 9},
+	}
+	for _, test := range tests {
+		jugs := &puzzles.Jugs{Caps: test.caps, Target: test.target, Goal: test.goal, NoTap: test.notap}
+		start, err := jugs.Start(test.levels)
+		if err != nil {
+			t.Fatalf("jugs %v: %v", test.caps, err)
+		}
+		if got := moves(t, start, search.BreadthFirstContext); got != test.moves {
+			t.Errorf("jugs %v target %d goal %v: %d moves, expected %d", test.caps, test.target, test.goal, got, test.moves)
+		}
+	}
+}
+
+func TestJugsInvalid(t *testing.T) {
+	tests := []struct {
+		caps   []int
+		levels []int
+	}{
+		{nil, nil},
+		{[]int{3, 0}, nil},
+		{[]int{3, 5}, []int{4, 0}},
+		{[]int{3, 5}, []int{1}},
+	}
+	for _, test := range tests {
+		jugs := &puzzles.Jugs{Caps: test.caps, Target: 1}
+		if _, err := jugs.Start(test.levels); err == nil {
+			t.Errorf("jugs %v with levels %v: no error", test.caps, test.levels)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// puzzles.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Package puzzles has classic state space puzzles as states for hduplooy/gosearch
+// They are the water jugs, missionaries and cannibals, Towers of Hanoi and the wolf, goat and cabbage puzzles
+// Every move costs 1 so BreadthFirstSearch (or BestCostSearch) finds the shortest solution
+package puzzles
+
+import (
+	"fmt"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Step is a state of one of the puzzles
+// Move describes the move that led to the state (empty for the start) and String the state itself
+type Step interface {
+	src.SearchF
+	Move() string
+	String() string
+}
+
+// Solution returns every state from the start to the answer given the history and answer of a search
+func Solution(hist []src.SearchF, ans src.SearchF) []Step {
+	tmp := make([]Step, 0, len(hist)+1)
+	for i := len(hist) - 1; i >= 0; i-- {
+		tmp = append(tmp, hist[i].(Step))
+	}
+	return append(tmp, ans.(Step))
+}
+
+// Print returns the solution with one state per line, each after the move that led to it
+func Print(steps []Step) string {
+	width := 0
+	for _, step := range steps {
+		if len(step.Move()) > width {
+			width = len(step.Move())
+		}
+	}
+	var buf strings.Builder
+	for i, step := range steps {
+		line := fmt.Sprintf("%3d %-*s  %s", i, width, step.Move(), step)
+		buf.WriteString(strings.TrimRight(line, " "))
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
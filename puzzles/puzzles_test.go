@@ -0,0 +1,34 @@
+// puzzles_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Helpers shared by the tests that solve the puzzles with known shortest solutions
+package puzzles_test
+
+import (
+	"context"
+	"testing"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/puzzles"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// moves solves the puzzle from start with the search given and returns the number of moves of the solution
+// (-1 if there is none), the solution is checked to run from start to a goal one move at a time
+func moves(t *testing.T, start src.SearchF, find func(context.Context, src.SearchF, search.Limits) (*search.Result, error)) int {
+	t.Helper()
+	res, _ := find(context.Background(), start, search.Limits{})
+	if res.Goal == nil {
+		return -1
+	}
+	steps := puzzles.Solution(res.History(), res.Goal)
+	if steps[0].Key() != start.Key() || !steps[len(steps)-1].Done() {
+		t.Errorf("the solution does not run from the start to a goal:\n%s", puzzles.Print(steps))
+	}
+	for i, step := range steps {
+		if int(step.Cost()) != i {
+			t.Errorf("step %d has cost %v", i, step.Cost())
+		}
+	}
+	return len(steps) - 1
+}
@@ -0,0 +1,112 @@
+// river.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// The missionaries and cannibals puzzle
+// Missionaries and cannibals must cross a river in a boat that holds at most Boat people and needs at least one to row it
+// If the cannibals ever outnumber the missionaries on a bank (or in the boat) where there are missionaries, they eat them
+package puzzles
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// River holds the number of missionaries and cannibals and how many the boat holds
+type River struct {
+	Missionaries int
+	Cannibals    int
+	Boat         int
+}
+
+// NewRiver returns the start state with everyone and the boat on the left bank
+func NewRiver(missionaries, cannibals, boat int) (*Bank, error) {
+	if missionaries < 0 || cannibals < 0 || missionaries+cannibals < 1 {
+		return nil, errors.New("there must be someone to cross the river")
+	}
+	if boat < 1 {
+		return nil, errors.New("the boat must hold at least one")
+	}
+	if missionaries > 0 && cannibals > missionaries {
+		return nil, errors.New("the cannibals already outnumber the missionaries")
+	}
+	return &Bank{&River{missionaries, cannibals, boat}, missionaries, cannibals, true, 0, ""}, nil
+}
+
+// Bank is the state with M missionaries and C cannibals still on the left bank, Left is true if the boat is there
+// Trips is the number of times the river was crossed and move the last crossing
+type Bank struct {
+	*River
+	M     int
+	C     int
+	Left  bool
+	Trips int
+	move  string
+}
+
+// safe is true if the missionaries are not outnumbered by the cannibals
+func safe(m, c int) bool {
+	return m == 0 || m >= c
+}
+
+// Descendants takes every safe group of 1 up to Boat people across with the boat
+func (bank *Bank) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0)
+	// The number of missionaries and cannibals on the bank where the boat is
+	hereM, hereC := bank.M, bank.C
+	dir := -1
+	if !bank.Left {
+		hereM, hereC = bank.Missionaries-bank.M, bank.Cannibals-bank.C
+		dir = 1
+	}
+	for m := 0; m <= hereM && m <= bank.Boat; m++ {
+		for c := 0; c <= hereC && m+c <= bank.Boat; c++ {
+			if m+c == 0 || !safe(m, c) {
+				continue
+			}
+			nm, nc := bank.M+dir*m, bank.C+dir*c
+			if !safe(nm, nc) || !safe(bank.Missionaries-nm, bank.Cannibals-nc) {
+				continue
+			}
+			side := "right"
+			if !bank.Left {
+				side = "left"
+			}
+			move := fmt.Sprintf("%dM %dC to the %s", m, c, side)
+			tmp = append(tmp, &Bank{bank.River, nm, nc, !bank.Left, bank.Trips + 1, move})
+		}
+	}
+	return tmp
+}
+
+// Done is true when everyone is on the right bank
+func (bank *Bank) Done() bool {
+	return bank.M == 0 && bank.C == 0 && !bank.Left
+}
+
+// Cost is the number of crossings
+func (bank *Bank) Cost() float64 { return float64(bank.Trips) }
+
+// Away is not used
+func (bank *Bank) Away() float64 { return 0.0 }
+
+// Key is the people on the left bank and where the boat is
+func (bank *Bank) Key() string {
+	return fmt.Sprintf("%d,%d,%t", bank.M, bank.C, bank.Left)
+}
+
+// Move is the last crossing
+func (bank *Bank) Move() string { return bank.move }
+
+// String draws the two banks with the river and boat between them
+func (bank *Bank) String() string {
+	left := strings.Repeat("M", bank.M) + strings.Repeat("C", bank.C)
+	right := strings.Repeat("M", bank.Missionaries-bank.M) + strings.Repeat("C", bank.Cannibals-bank.C)
+	river := "B~~~~~~ "
+	if !bank.Left {
+		river = " ~~~~~~B"
+	}
+	return fmt.Sprintf("%*s %s %s", bank.Missionaries+bank.Cannibals, left, river, right)
+}
@@ -0,0 +1,43 @@
+// river_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Solves missionaries and cannibals puzzles with known shortest solutions
+package puzzles_test
+
+import (
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/puzzles"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+func TestRiver(t *testing.T) {
+	// Puzzles with the number of crossings of their shortest solutions (-1 if there is none)
+	tests := []struct {
+		missionaries int
+		cannibals    int
+		boat         int
+		trips        int
+	}{
+		{1, 1, 1, -1},
+		{2, 2, 1, -1},
+		{2, 2, 2, 5},
+		{3, 2, 2, 7},
+		{3, 3, 2, 11},
+		{4, 4, 2, -1},
+		{4, 4, 3, 9},
+		{5, 5, 3, 11},
+		{6, 6, 3, -1},
+		{6, 6, 4, 9},
+	}
+	for _, test := range tests {
+		start, err := puzzles.NewRiver(test.missionaries, test.cannibals, test.boat)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := moves(t, start, search.BreadthFirstContext); got != test.trips {
+			t.Errorf("%d missionaries %d cannibals boat %d: %d crossings, expected %d",
+				test.missionaries, test.cannibals, test.boat, got, test.trips)
+		}
+	}
+}
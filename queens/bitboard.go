@@ -0,0 +1,140 @@
+// bitboard.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// A faster N queens state that keeps the taken files and diagonals as bitmasks
+// Finding the free squares on the next rank is then a couple of bit operations instead of checking every queen placed
+package queens
+
+import (
+	"math/bits"
+	"strconv"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// MaxSize is the biggest board that a BitBoard can handle
+const MaxSize = 32
+
+// BitBoard is the state of the board using bitmasks
+// Size is the number of ranks (and files) on the board
+// Rank is the number of queens placed so far (they are on the first Rank ranks)
+// Cols has a bit set for every file that already has a queen
+// Left and Right have a bit set for every file on the next rank that is attacked diagonally
+// Files is the file of the queen on each of the ranks placed so far
+// It is a fixed array so that making a descendant is just a copy and does not need any allocation
+type BitBoard struct {
+	Size  int
+	Rank  int
+	Cols  uint64
+	Left  uint64
+	Right uint64
+	Files [MaxSize]int8
+}
+
+// NewBitBoard returns an empty board of size n (n may not be bigger than MaxSize)
+func NewBitBoard(n int) BitBoard {
+	if n > MaxSize {
+		panic("queens: board size " + strconv.Itoa(n) + " is bigger than " + strconv.Itoa(MaxSize))
+	}
+	return BitBoard{Size: n}
+}
+
+// full returns the mask with all the files of the board set
+func (brd *BitBoard) full() uint64 {
+	return 1<<uint(brd.Size) - 1
+}
+
+// Free returns a mask of all the files on the next rank where a queen can be placed
+func (brd *BitBoard) Free() uint64 {
+	return ^(brd.Cols | brd.Left | brd.Right) & brd.full()
+}
+
+// Place returns the state after placing a queen in file on the next rank
+// The file is not checked, use Free to find the valid ones
+func (brd BitBoard) Place(file int) BitBoard {
+	bit := uint64(1) << uint(file)
+	brd.Files[brd.Rank] = int8(file)
+	brd.Rank++
+	brd.Cols |= bit
+	brd.Left = (brd.Left | bit) << 1
+	brd.Right = (brd.Right | bit) >> 1
+	return brd
+}
+
+// Expand calls fn for every valid descendant without allocating anything
+// If fn returns false no further descendants are generated and false is returned
+func (brd *BitBoard) Expand(fn func(BitBoard) bool) bool {
+	for free := brd.Free(); free != 0; free &= free - 1 {
+		if !fn(brd.Place(bits.TrailingZeros64(free))) {
+			return false
+		}
+	}
+	return true
+}
+
+// Descendants return all valid entries on the next rank based on the current position
+func (brd BitBoard) Descendants() []src.SearchF {
+	free := brd.Free()
+	tmp := make([]src.SearchF, 0, bits.OnesCount64(free))
+	for ; free != 0; free &= free - 1 {
+		tmp = append(tmp, brd.Place(bits.TrailingZeros64(free)))
+	}
+	return tmp
+}
+
+// Done check if done and this is the case if all the ranks have a queen
+func (brd BitBoard) Done() bool {
+	return brd.Rank == brd.Size
+}
+
+// Cost is not used
+func (brd BitBoard) Cost() float64 { return 0.0 }
+
+// Away is not used
+func (brd BitBoard) Away() float64 { return 0.0 }
+
+// Key returns the same key as Board does for the same placement
+// It is built in a single byte slice instead of joining a string per rank
+func (brd BitBoard) Key() string {
+	tmp := make([]byte, 0, 3*brd.Rank)
+	for i := 0; i < brd.Rank; i++ {
+		if i > 0 {
+			tmp = append(tmp, ',')
+		}
+		tmp = strconv.AppendInt(tmp, int64(brd.Files[i]), 10)
+	}
+	return string(tmp)
+}
+
+// Board converts the state to a Board (to print it or to reduce it under the symmetries)
+func (brd BitBoard) Board() Board {
+	tmp := make([]int, brd.Rank, brd.Size)
+	for i := range tmp {
+		tmp[i] = int(brd.Files[i])
+	}
+	return Board{brd.Size, tmp}
+}
+
+// String draws the board the same way as Board does
+func (brd BitBoard) String() string {
+	return brd.Board().String()
+}
+
+// count returns the number of solutions below the state given by the masks
+func count(full, cols, left, right uint64) int {
+	if cols == full {
+		return 1
+	}
+	tot := 0
+	for free := ^(cols | left | right) & full; free != 0; free &= free - 1 {
+		bit := free & -free
+		tot += count(full, cols|bit, (left|bit)<<1, (right|bit)>>1)
+	}
+	return tot
+}
+
+// Count returns the number of solutions that can be reached from the state
+// It only works on the masks and is the fastest way to count the solutions
+func (brd BitBoard) Count() int {
+	return count(brd.full(), brd.Cols, brd.Left, brd.Right)
+}
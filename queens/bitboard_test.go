@@ -0,0 +1,112 @@
+// bitboard_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks BitBoard against Board and compares how fast the two generate states for board sizes 8 to 20
+// Run the benchmarks with go test -bench . ./queens
+package queens_test
+
+import (
+	"fmt"
+	"testing"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/queens"
+)
+
+// nodes is the number of nodes expanded in every iteration of the benchmarks so that the big boards finish as well
+const nodes = 10000
+
+// walk does a depth first search from start through the SearchF interface until limit nodes are expanded
+// Key is called on every node just like the gosearch routines do for their closed set
+func walk(start src.SearchF, limit int) int {
+	cnt := 0
+	stack := []src.SearchF{start}
+	for len(stack) > 0 && cnt < limit {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		cnt++
+		_ = cur.Key()
+		stack = append(stack, cur.Descendants()...)
+	}
+	return cnt
+}
+
+// expand does a depth first search from brd using Expand until the node counter reaches limit
+func expand(brd queens.BitBoard, cnt *int, limit int) bool {
+	if *cnt >= limit {
+		return false
+	}
+	*cnt++
+	return brd.Expand(func(child queens.BitBoard) bool {
+		return expand(child, cnt, limit)
+	})
+}
+
+// keys returns the keys of the states in the order they are given
+func keys(states []src.SearchF) []string {
+	tmp := make([]string, len(states))
+	for i, st := range states {
+		tmp[i] = st.Key()
+	}
+	return tmp
+}
+
+// compare checks that Expand of brd gives the same children as Descendants of the same Board and does the same below them
+func compare(t *testing.T, brd queens.BitBoard) {
+	var got []src.SearchF
+	brd.Expand(func(child queens.BitBoard) bool {
+		got = append(got, child)
+		return true
+	})
+	want := keys(brd.Board().Descendants())
+	if fmt.Sprint(keys(got)) != fmt.Sprint(want) {
+		t.Fatalf("N=%d at %q: Expand gives %v, Descendants gives %v", brd.Size, brd.Key(), keys(got), want)
+	}
+	for _, child := range got {
+		compare(t, child.(queens.BitBoard))
+	}
+}
+
+func TestExpandMatchesDescendants(t *testing.T) {
+	for n := 1; n <= 8; n++ {
+		compare(t, queens.NewBitBoard(n))
+	}
+}
+
+func TestBitBoardCount(t *testing.T) {
+	for n := 1; n <= len(allCounts); n++ {
+		if got := queens.NewBitBoard(n).Count(); got != allCounts[n-1] {
+			t.Errorf("N=%d: Count gives %d, expected %d", n, got, allCounts[n-1])
+		}
+	}
+}
+
+// bench runs fn for board sizes 8 to 20 and reports the time per node
+func bench(b *testing.B, fn func(n int) int) {
+	for n := 8; n <= 20; n++ {
+		b.Run(fmt.Sprintf("N=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			cnt := 0
+			for i := 0; i < b.N; i++ {
+				cnt += fn(n)
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(cnt), "ns/node")
+		})
+	}
+}
+
+func BenchmarkBoard(b *testing.B) {
+	bench(b, func(n int) int { return walk(queens.NewBoard(n), nodes) })
+}
+
+func BenchmarkBitBoard(b *testing.B) {
+	bench(b, func(n int) int { return walk(queens.NewBitBoard(n), nodes) })
+}
+
+func BenchmarkBitBoardExpand(b *testing.B) {
+	bench(b, func(n int) int {
+		cnt := 0
+		expand(queens.NewBitBoard(n), &cnt, nodes)
+		return cnt
+	})
+}
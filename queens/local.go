@@ -0,0 +1,218 @@
+// local.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// A complete assignment of N queens for the local search routines of the search package
+// Every rank and every file has exactly one queen (the files are a permutation) so only diagonals can be in conflict
+// A move swaps the files of two ranks
+package queens
+
+import (
+	"math/rand"
+
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// Local is the complete assignment
+// Files is the file of the queen on each rank
+// Down and Up count the queens on every diagonal (rank+file and rank-file+size-1)
+// Total is the number of pairs of queens that can capture each other
+// conflicted holds ranks that were in conflict when last checked, it is only rebuilt when it runs empty
+type Local struct {
+	Size       int
+	Files      []int32
+	Down       []int32
+	Up         []int32
+	Total      int
+	conflicted []int32
+}
+
+// NewLocal returns a starting assignment for a board of size n
+func NewLocal(n int, rnd *rand.Rand) *Local {
+	st := &Local{
+		Size:  n,
+		Files: make([]int32, n),
+		Down:  make([]int32, 2*n),
+		Up:    make([]int32, 2*n),
+	}
+	st.Restart(rnd)
+	return st
+}
+
+// add puts the queen of rank on file and updates the counts
+func (st *Local) add(rank, file int) {
+	d, u := rank+file, rank-file+st.Size-1
+	st.Total += int(st.Down[d] + st.Up[u])
+	st.Down[d]++
+	st.Up[u]++
+	st.Files[rank] = int32(file)
+}
+
+// remove takes the queen of rank off the board and updates the counts
+func (st *Local) remove(rank int) {
+	file := int(st.Files[rank])
+	d, u := rank+file, rank-file+st.Size-1
+	st.Down[d]--
+	st.Up[u]--
+	st.Total -= int(st.Down[d] + st.Up[u])
+}
+
+// swap exchanges the files of ranks a and b
+func (st *Local) swap(a, b int) {
+	fa, fb := int(st.Files[a]), int(st.Files[b])
+	st.remove(a)
+	st.remove(b)
+	st.add(a, fb)
+	st.add(b, fa)
+}
+
+// attacked returns the number of other queens that can capture the queen on rank
+func (st *Local) attacked(rank int) int {
+	file := int(st.Files[rank])
+	return int(st.Down[rank+file]+st.Up[rank-file+st.Size-1]) - 2
+}
+
+// Restart places the queens greedily rank by rank
+// For every rank a few random files that are still open are tried looking for one without a diagonal conflict
+// Towards the end, when few files are left, all of them are tried
+// Only when none is found a conflict is accepted, so very few conflicts are left for the local search
+func (st *Local) Restart(rnd *rand.Rand) {
+	n := st.Size
+	for i := range st.Down {
+		st.Down[i] = 0
+		st.Up[i] = 0
+	}
+	st.Total = 0
+	// The files still open are kept in free[rank:]
+	free := make([]int32, n)
+	for i := range free {
+		free[i] = int32(i)
+	}
+	for rank := 0; rank < n; rank++ {
+		pick := rank
+		tries, off := 200, rnd.Intn(n-rank)
+		if n-rank <= 1000 {
+			tries = n - rank
+		}
+		for try := 0; try < tries; try++ {
+			j := rank + rnd.Intn(n-rank)
+			if tries == n-rank {
+				j = rank + (off+try)%(n-rank)
+			}
+			file := int(free[j])
+			pick = j
+			if st.Down[rank+file] == 0 && st.Up[rank-file+n-1] == 0 {
+				break
+			}
+		}
+		free[rank], free[pick] = free[pick], free[rank]
+		st.add(rank, int(free[rank]))
+	}
+	st.conflicted = st.conflicted[:0]
+}
+
+// Conflicts returns the number of pairs of queens that can capture each other
+func (st *Local) Conflicts() int {
+	return st.Total
+}
+
+// pickConflicted returns a random rank whose queen is in conflict (there must be conflicts)
+// Ranks in the list that are no longer in conflict are dropped and the list is only rebuilt once it is empty,
+// so that a big board is not scanned after every move
+func (st *Local) pickConflicted(rnd *rand.Rand) int {
+	for {
+		if len(st.conflicted) == 0 {
+			for rank := 0; rank < st.Size; rank++ {
+				if st.attacked(rank) > 0 {
+					st.conflicted = append(st.conflicted, int32(rank))
+				}
+			}
+		}
+		i := rnd.Intn(len(st.conflicted))
+		rank := int(st.conflicted[i])
+		if st.attacked(rank) > 0 {
+			return rank
+		}
+		last := len(st.conflicted) - 1
+		st.conflicted[i] = st.conflicted[last]
+		st.conflicted = st.conflicted[:last]
+	}
+}
+
+// delta returns the change in conflicts if the files of ranks a and b are swapped
+func (st *Local) delta(a, b int) int {
+	before := st.Total
+	st.swap(a, b)
+	after := st.Total
+	st.swap(a, b)
+	return after - before
+}
+
+// BestMove takes a random rank in conflict and finds the rank to swap with that leaves the fewest conflicts
+// Ties are broken randomly
+func (st *Local) BestMove(rnd *rand.Rand) (search.Move, int) {
+	a := st.pickConflicted(rnd)
+	best, bestDelta, ties := a, 0, 0
+	for b := 0; b < st.Size; b++ {
+		if b == a {
+			continue
+		}
+		d := st.delta(a, b)
+		switch {
+		case ties == 0 || d < bestDelta:
+			best, bestDelta, ties = b, d, 1
+		case d == bestDelta:
+			// Keep each of the tied ranks with equal probability
+			ties++
+			if rnd.Intn(ties) == 0 {
+				best = b
+			}
+		}
+	}
+	return search.Move{A: a, B: best}, bestDelta
+}
+
+// RandomMove takes a random rank in conflict and a random rank to swap with
+func (st *Local) RandomMove(rnd *rand.Rand) (search.Move, int) {
+	a := st.pickConflicted(rnd)
+	b := rnd.Intn(st.Size - 1)
+	if b >= a {
+		b++
+	}
+	return search.Move{A: a, B: b}, st.delta(a, b)
+}
+
+// Apply swaps the files of the ranks in the move
+// The ranks are added to the conflicted ones if they are now in conflict
+func (st *Local) Apply(m search.Move) {
+	st.swap(m.A, m.B)
+	for _, rank := range []int{m.A, m.B} {
+		if st.attacked(rank) > 0 {
+			st.conflicted = append(st.conflicted, int32(rank))
+		}
+	}
+}
+
+// Valid checks from scratch that no queen can capture another
+func (st *Local) Valid() bool {
+	files := make([]bool, st.Size)
+	down := make([]bool, 2*st.Size)
+	up := make([]bool, 2*st.Size)
+	for rank, val := range st.Files {
+		file := int(val)
+		d, u := rank+file, rank-file+st.Size-1
+		if files[file] || down[d] || up[u] {
+			return false
+		}
+		files[file], down[d], up[u] = true, true, true
+	}
+	return true
+}
+
+// Board converts the assignment to a Board to print it
+func (st *Local) Board() Board {
+	tmp := make([]int, st.Size)
+	for i, val := range st.Files {
+		tmp[i] = int(val)
+	}
+	return Board{st.Size, tmp}
+}
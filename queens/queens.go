@@ -0,0 +1,102 @@
+// queens.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Package queens implements the N queens problem as a state for hduplooy/gosearch
+// Place N queens on an NXN chess board without any one queen able to capture another
+package queens
+
+import (
+	"strconv"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Board is the state of the board
+// Size is the number of ranks (and files) on the board
+// Files has an entry for each rank placed so far, the value is the file of the queen on that rank
+type Board struct {
+	Size  int
+	Files []int
+}
+
+// NewBoard returns an empty board of size n
+func NewBoard(n int) Board {
+	return Board{n, make([]int, 0, n)}
+}
+
+// A simple integer abs function
+func iabs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Safe checks if a queen can be placed on the next rank in file without being captured by the queens already placed
+func (brd Board) Safe(file int) bool {
+	sz := len(brd.Files)
+	for j, val := range brd.Files {
+		// If it is in the same file (column) or diagonally they are the same then it is not a valid move
+		if file == val || iabs(val-file) == sz-j {
+			return false
+		}
+	}
+	return true
+}
+
+// Descendants return all valid entries on the next rank based on the current position
+func (brd Board) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0, brd.Size)
+	sz := len(brd.Files)
+	for i := 0; i < brd.Size; i++ {
+		if !brd.Safe(i) {
+			continue
+		}
+		// Copy state up to previous and set the new rank+file
+		tmp2 := make([]int, sz+1, brd.Size)
+		copy(tmp2, brd.Files)
+		tmp2[sz] = i
+		tmp = append(tmp, Board{brd.Size, tmp2})
+	}
+	return tmp
+}
+
+// Done check if done and this is the case if all the ranks have a queen
+func (brd Board) Done() bool {
+	return len(brd.Files) == brd.Size
+}
+
+// Cost is not used
+func (brd Board) Cost() float64 { return 0.0 }
+
+// Away is not used
+func (brd Board) Away() float64 { return 0.0 }
+
+// Key returns a unique key describing the state
+// The files are separated by commas because for boards bigger than 10 a file can take more than one digit
+func (brd Board) Key() string {
+	tmp := make([]string, len(brd.Files))
+	for i, val := range brd.Files {
+		tmp[i] = strconv.Itoa(val)
+	}
+	return strings.Join(tmp, ",")
+}
+
+// String draws the board with a Q where the queens are placed
+func (brd Board) String() string {
+	line := strings.Repeat("+-", brd.Size) + "+\n"
+	tmp := line
+	for _, val := range brd.Files {
+		tmp += "|"
+		for i := 0; i < brd.Size; i++ {
+			if i == val {
+				tmp += "Q|"
+			} else {
+				tmp += " |"
+			}
+		}
+		tmp += "\n" + line
+	}
+	return tmp
+}
@@ -0,0 +1,51 @@
+// queens_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks the N queens solutions found by exhaustive search against the known counts
+package queens_test
+
+import (
+	"testing"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/queens"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// Known number of solutions for boards of size 1 to 10 (OEIS A000170)
+var allCounts = []int{1, 0, 0, 2, 10, 4, 40, 92, 352, 724}
+
+// Known number of fundamental solutions for boards of size 1 to 10 (OEIS A002562)
+var distinctCounts = []int{1, 0, 0, 1, 2, 1, 6, 12, 46, 92}
+
+func TestEnumerateCounts(t *testing.T) {
+	for n := 1; n <= len(allCounts); n++ {
+		all := 0
+		canon := map[string]bool{}
+		search.Enumerate(queens.NewBoard(n), func(ans src.SearchF) bool {
+			brd := ans.(queens.Board)
+			all++
+			canon[brd.Canonical().Key()] = true
+			return true
+		})
+		if all != allCounts[n-1] {
+			t.Errorf("N=%d: got %d solutions, expected %d", n, all, allCounts[n-1])
+		}
+		if len(canon) != distinctCounts[n-1] {
+			t.Errorf("N=%d: got %d distinct solutions, expected %d", n, len(canon), distinctCounts[n-1])
+		}
+	}
+}
+
+func TestEnumerateStops(t *testing.T) {
+	for _, stop := range []int{1, 5, 92} {
+		got := 0
+		search.Enumerate(queens.NewBoard(8), func(ans src.SearchF) bool {
+			got++
+			return got < stop
+		})
+		if got != stop {
+			t.Errorf("stopping after %d solutions: found was called %d times", stop, got)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// symmetry.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// The 8 symmetries of the square board (rotations and reflections) applied to complete N queens solutions
+package queens
+
+// Symmetries is the number of ways a square board can be rotated or reflected onto itself (including leaving it as is)
+const Symmetries = 8
+
+// transform maps the square at rank r and file f to where it ends up under symmetry t on a board of size n
+// 0 is the identity, 1-3 the rotations by 90, 180 and 270 degrees and 4-7 the reflections
+// (left to right, top to bottom, main diagonal and anti diagonal)
+func transform(t, n, r, f int) (int, int) {
+	m := n - 1
+	switch t {
+	case 1:
+		return f, m - r
+	case 2:
+		return m - r, m - f
+	case 3:
+		return m - f, r
+	case 4:
+		return r, m - f
+	case 5:
+		return m - r, f
+	case 6:
+		return f, r
+	case 7:
+		return m - f, m - r
+	}
+	return r, f
+}
+
+// Transform returns the board after applying symmetry t (0 to Symmetries-1) to it
+// Only complete boards can be transformed, because a rotated partial board no longer has its queens on the first ranks
+func (brd Board) Transform(t int) Board {
+	tmp := make([]int, brd.Size)
+	for r, f := range brd.Files {
+		r2, f2 := transform(t, brd.Size, r, f)
+		tmp[r2] = f2
+	}
+	return Board{brd.Size, tmp}
+}
+
+// less compares the files of two boards of the same size lexicographically
+func less(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// Canonical returns the representative of the solution's symmetry class
+// It is the lexicographically smallest of the 8 images of the board
+func (brd Board) Canonical() Board {
+	best := brd
+	for t := 1; t < Symmetries; t++ {
+		tmp := brd.Transform(t)
+		if less(tmp.Files, best.Files) {
+			best = tmp
+		}
+	}
+	return best
+}
+
+// IsCanonical is true if the board is the representative of its symmetry class
+// Counting only the canonical solutions gives the number of fundamental (distinct) solutions
+func (brd Board) IsCanonical() bool {
+	for t := 1; t < Symmetries; t++ {
+		if less(brd.Transform(t).Files, brd.Files) {
+			return false
+		}
+	}
+	return true
+}
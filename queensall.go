@@ -0,0 +1,54 @@
+// queensall.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Enumerates all the solutions of the N queens problem instead of stopping at the first one like 8queensdepth.go
+// Solutions can be reduced under the 8 symmetries of the board so that only the fundamental ones are reported
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/queens"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// enumerate finds all the solutions on a board of size n
+// found is called for each solution (only the canonical ones if distinct is set)
+// The number of steps, all solutions and the distinct solutions are returned
+func enumerate(n int, distinct bool, found func(queens.Board)) (int, int, int) {
+	all, dist := 0, 0
+	cnt := search.Enumerate(queens.NewBoard(n), func(ans src.SearchF) bool {
+		brd := ans.(queens.Board)
+		all++
+		canon := brd.IsCanonical()
+		if canon {
+			dist++
+		}
+		if found != nil && (canon || !distinct) {
+			found(brd)
+		}
+		return true
+	})
+	return cnt, all, dist
+}
+
+func main() {
+	n := flag.Int("n", 8, "size of the board")
+	distinct := flag.Bool("distinct", false, "only report solutions that are distinct under rotation and reflection")
+	show := flag.Bool("print", false, "print every solution as it is found")
+	flag.Parse()
+
+	var found func(queens.Board)
+	if *show {
+		num := 0
+		found = func(brd queens.Board) {
+			num++
+			fmt.Printf("Solution %d\n%v", num, brd)
+		}
+	}
+	cnt, all, dist := enumerate(*n, *distinct, found)
+	fmt.Printf("Done in %d steps\n", cnt)
+	fmt.Printf("%d solutions, %d distinct\n", all, dist)
+}
@@ -0,0 +1,59 @@
+// queenslocal.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Solves the N queens problem for very big boards (a million queens and more) with local search
+// DepthFirstSearch as in 8queensdepth.go is of no use at these sizes
+// By default min-conflicts is used, with -anneal simulated annealing is used instead
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/hduplooy/gosearch-test/queens"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+func main() {
+	n := flag.Int("n", 1000000, "size of the board")
+	anneal := flag.Bool("anneal", false, "use simulated annealing instead of min-conflicts")
+	steps := flag.Int("steps", 10000, "steps before restarting")
+	restarts := flag.Int("restarts", 10, "maximum number of restarts")
+	temp := flag.Float64("temp", 0.5, "starting temperature for simulated annealing")
+	cooling := flag.Float64("cooling", 0.999, "factor the temperature is multiplied by after every step")
+	seed := flag.Int64("seed", 1, "seed for the random numbers")
+	show := flag.Bool("print", false, "print the board (only sensible for small boards)")
+	flag.Parse()
+
+	if *n < 1 || *n == 2 || *n == 3 {
+		fmt.Fprintf(os.Stderr, "There is no solution for a board of size %d\n", *n)
+		os.Exit(1)
+	}
+	rnd := rand.New(rand.NewSource(*seed))
+	start := time.Now()
+	st := queens.NewLocal(*n, rnd)
+	fmt.Printf("Started with %d conflicts in %v\n", st.Conflicts(), time.Since(start))
+	var ok bool
+	var stats search.LocalStats
+	if *anneal {
+		ok, stats = search.SimulatedAnnealing(st, *temp, *cooling, *steps, *restarts, rnd)
+	} else {
+		ok, stats = search.MinConflicts(st, *steps, *restarts, rnd)
+	}
+	fmt.Printf("Done in %d steps (%d moves, %d restarts) in %v\n", stats.Steps, stats.Moves, stats.Restarts, time.Since(start))
+	if !ok {
+		fmt.Printf("No solution found, %d conflicts left\n", st.Conflicts())
+		os.Exit(1)
+	}
+	if !st.Valid() {
+		fmt.Println("The solution is not valid")
+		os.Exit(1)
+	}
+	fmt.Println("Solution verified")
+	if *show {
+		fmt.Print(st.Board())
+	}
+}
@@ -0,0 +1,85 @@
+// queensparallel.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Counts all the solutions of the N queens problem like queensall.go but with EnumerateParallel of the search package,
+// which shares out the subtrees among goroutines by work stealing
+// For every board size from -from to -to the count is timed with Enumerate and with every number of -workers and
+// the speedup over Enumerate is printed, it can only go up with the workers if there are as many CPUs
+// The counts are checked against the known values (OEIS A000170)
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/queens"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// Known number of solutions for boards of size 1 to 16 (OEIS A000170)
+var allCounts = []int{1, 0, 0, 2, 10, 4, 40, 92, 352, 724, 2680, 14200, 73712, 365596, 2279184, 14772512}
+
+// row prints a line of the table and returns false if the count is not the known one
+func row(n int, name string, goals, steps, steals int, dur, base time.Duration) bool {
+	status := "ok"
+	if n <= len(allCounts) && goals != allCounts[n-1] {
+		status = fmt.Sprintf("expected %d", allCounts[n-1])
+	}
+	fmt.Printf("%3d %-10s %10d %12d %8d %14v %7.2f %s\n", n, name, goals, steps, steals, dur.Round(time.Millisecond), float64(base)/float64(dur), status)
+	return status == "ok"
+}
+
+func main() {
+	from := flag.Int("from", 14, "smallest board size")
+	to := flag.Int("to", 16, "biggest board size")
+	counts := flag.String("workers", "1,2,4,8", "the numbers of workers to compare, separated by commas")
+	timeout := flag.Duration("timeout", 0, "stop every count after this long (0 for no limit)")
+	flag.Parse()
+
+	var workers []int
+	for _, val := range strings.Split(*counts, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(val))
+		if err != nil || n < 1 {
+			fmt.Fprintf(os.Stderr, "Invalid number of workers %q\n", val)
+			os.Exit(2)
+		}
+		workers = append(workers, n)
+	}
+	fmt.Printf("%d CPUs, GOMAXPROCS %d\n", runtime.NumCPU(), runtime.GOMAXPROCS(0))
+	fmt.Printf("%3s %-10s %10s %12s %8s %14s %7s\n", "N", "Search", "Solutions", "Steps", "Steals", "Time", "Speedup")
+	fine := true
+	for n := *from; n <= *to; n++ {
+		goals := 0
+		tm := time.Now()
+		steps := search.Enumerate(queens.NewBitBoard(n), func(src.SearchF) bool {
+			goals++
+			return true
+		})
+		base := time.Since(tm)
+		fine = row(n, "Enumerate", goals, steps, 0, base, base) && fine
+		for _, num := range workers {
+			ctx, cancel := context.Background(), context.CancelFunc(func() {})
+			if *timeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, *timeout)
+			}
+			res, err := search.EnumerateParallel(ctx, queens.NewBitBoard(n), num, nil)
+			cancel()
+			if err != nil {
+				fmt.Printf("%3d %-10s stopped after %d steps and %d solutions: %v\n", n, fmt.Sprintf("%d workers", num), res.Expanded, res.Goals, err)
+				fine = false
+				continue
+			}
+			fine = row(n, fmt.Sprintf("%d workers", num), res.Goals, res.Expanded, res.Steals, res.Time, base) && fine
+		}
+	}
+	if !fine {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,299 @@
+// roads.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Package roads has the network of South African cities and the roads between them used by the city search examples
+// as a state for hduplooy/gosearch, so that the programs that need it do not each have their own copy
+// A Trip is a route from a city to a destination that may not visit a city twice
+package roads
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// City keeps the city information
+// Name is the name of the city
+// Neighbours are all the cities that can be reached directly
+// Distances are the distances in km from this city to its neighbours
+// Latitude and Longitude is the actual geo coordinates of the city
+// Key is the number of when it was created (so it is unique)
+type City struct {
+	Name       string
+	Neighbours []*City
+	Distances  []float64
+	Latitude   float64
+	Longitude  float64
+	Key        int
+}
+
+// Network is a database of cities by name
+type Network struct {
+	Cities map[string]*City
+}
+
+// NewNetwork returns an empty network
+func NewNetwork() *Network {
+	return &Network{make(map[string]*City)}
+}
+
+// city returns the city with the name, it is added if it is not in the network yet
+func (net *Network) city(name string) *City {
+	c, ok := net.Cities[name]
+	if !ok {
+		c = &City{Name: name, Key: len(net.Cities)}
+		net.Cities[name] = c
+	}
+	return c
+}
+
+// AddRoad adds the cities if they are not in the network and makes them each others neighbours at dist km
+func (net *Network) AddRoad(city1, city2 string, dist float64) {
+	c1, c2 := net.city(city1), net.city(city2)
+	c1.Neighbours = append(c1.Neighbours, c2)
+	c1.Distances = append(c1.Distances, dist)
+	c2.Neighbours = append(c2.Neighbours, c1)
+	c2.Distances = append(c2.Distances, dist)
+}
+
+// SetCoords sets the geo coordinates of a city already in the network
+func (net *Network) SetCoords(city string, lat, long float64) {
+	if c, ok := net.Cities[city]; ok {
+		c.Latitude = lat
+		c.Longitude = long
+	}
+}
+
+// Names returns the sorted names of the cities
+func (net *Network) Names() []string {
+	tmp := make([]string, 0, len(net.Cities))
+	for name := range net.Cities {
+		tmp = append(tmp, name)
+	}
+	sort.Strings(tmp)
+	return tmp
+}
+
+// SouthAfrica returns the network of the city search examples
+func SouthAfrica() *Network {
+	net := NewNetwork()
+	net.AddRoad("Pretoria", "Midrand", 28)
+	net.AddRoad("Midrand", "Johannesburg", 25)
+	net.AddRoad("Pretoria", "Kempton", 54)
+	net.AddRoad("Johannesburg", "Kempton", 25)
+	net.AddRoad("Johannesburg", "Klerksdorp", 172)
+	net.AddRoad("Klerksdorp", "Potchefstroom", 47)
+	net.AddRoad("Potchefstroom", "Kimberley", 358)
+	net.AddRoad("Johannesburg", "Vanderbijl", 72)
+	net.AddRoad("Vanderbijl", "Sasolburg", 17)
+	net.AddRoad("Johannesburg", "Vereeniging", 63)
+	net.AddRoad("Vereeniging", "Sasolburg", 29)
+	net.AddRoad("Johannesburg", "Kroonstad", 190)
+	net.AddRoad("Sasolburg", "Kroonstad", 124)
+	net.AddRoad("Kroonstad", "Ventersburg", 52)
+	net.AddRoad("Ventersburg", "Bloemfontein", 159)
+	net.AddRoad("Bloemfontein", "Kimberley", 168)
+	net.AddRoad("Bloemfontein", "Beaufort West", 570)
+	net.AddRoad("Kimberley", "Beaufort West", 453)
+	net.AddRoad("Beaufort West", "Worcester", 356)
+	net.AddRoad("Worcester", "Cape Town", 111)
+	net.AddRoad("Beaufort West", "George", 241)
+	net.AddRoad("George", "Cape Town", 431)
+	net.SetCoords("Pretoria", -25.7313, 28.2184)
+	net.SetCoords("Midrand", -25.98953, 28.12843)
+	net.SetCoords("Bloemfontein", -29.1183, 26.2249)
+	net.SetCoords("Cape Town", -33.9249, 18.4241)
+	net.SetCoords("Johannesburg", -26.2041, 28.0473)
+	net.SetCoords("Kempton", -26.1, 28.233334)
+	net.SetCoords("Klerksdorp", -26.859823, 26.631750)
+	net.SetCoords("Potchefstroom", -26.71667, 27.1)
+	net.SetCoords("Kimberley", -28.741943, 24.771944)
+	net.SetCoords("Vanderbijl", -26.703421, 27.807695)
+	net.SetCoords("Vereeniging", -26.673611, 27.931944)
+	net.SetCoords("Sasolburg", -26.810190, 27.827724)
+	net.SetCoords("Kroonstad", -27.644606, 27.250900)
+	net.SetCoords("Ventersburg", -28.08561, 27.13814)
+	net.SetCoords("Beaufort West", -32.35671, 22.58295)
+	net.SetCoords("Worcester", -33.64651, 19.44852)
+	net.SetCoords("George", -33.963, 22.46173)
+	return net
+}
+
+// Grid returns a big generated network to search on, with rows times cols cities named "R<row>C<col>"
+// The cities are on a grid (a tenth of a degree apart) moved a bit at random, every city has roads to the cities
+// next to it and some to the ones diagonally next to it, the roads are up to a third longer than the direct distance
+// The same seed gives the same network
+func Grid(rows, cols int, seed int64) *Network {
+	rnd := rand.New(rand.NewSource(seed))
+	net := NewNetwork()
+	name := func(r, c int) string { return fmt.Sprintf("R%dC%d", r, c) }
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			city := net.city(name(r, c))
+			city.Latitude = -22 - 0.1*float64(r) + 0.06*(rnd.Float64()-0.5)
+			city.Longitude = 17 + 0.1*float64(c) + 0.06*(rnd.Float64()-0.5)
+		}
+	}
+	road := func(r1, c1, r2, c2 int) {
+		n1, n2 := name(r1, c1), name(r2, c2)
+		net.AddRoad(n1, n2, net.Cities[n1].Distance(net.Cities[n2])*(1+rnd.Float64()/3))
+	}
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if c+1 < cols {
+				road(r, c, r, c+1)
+			}
+			if r+1 < rows {
+				road(r, c, r+1, c)
+			}
+			if r+1 < rows && c+1 < cols && rnd.Intn(3) == 0 {
+				road(r, c, r+1, c+1)
+			}
+		}
+	}
+	return net
+}
+
+// toRad converts degree values to radians
+func toRad(val float64) float64 {
+	return val * math.Pi / 180.0
+}
+
+// Distance is the distance in km between the cities based on their latitude and longitudes
+func (city *City) Distance(city2 *City) float64 {
+	dlat := toRad(city.Latitude - city2.Latitude)
+	dlon := toRad(city.Longitude - city2.Longitude)
+	lat1 := toRad(city.Latitude)
+	lat2 := toRad(city2.Latitude)
+	a1 := math.Sin(dlat / 2.0)
+	a2 := math.Sin(dlon / 2.0)
+	a := a1*a1 + a2*a2*math.Cos(lat1)*math.Cos(lat2)
+	return 6371.0 * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// Trip is the state of a search for a route (because we can actually go to the same city again by another route)
+// Includes City, the city reached
+// Route has the Key values of all the cities visited so far each between dashes, so this is unique for each state
+// TotCost is the total distance travelled so far
+// Destination is the goal
+type Trip struct {
+	*City
+	Route       string
+	TotCost     float64
+	Destination *City
+}
+
+// Start returns the trip from the city to the destination
+func (net *Network) Start(from, to string) (*Trip, error) {
+	city, ok := net.Cities[from]
+	if !ok {
+		return nil, fmt.Errorf("unknown city %q", from)
+	}
+	dest, ok := net.Cities[to]
+	if !ok {
+		return nil, fmt.Errorf("unknown city %q", to)
+	}
+	return &Trip{city, "-" + strconv.Itoa(city.Key) + "-", 0, dest}, nil
+}
+
+// Visited is true if the trip already went through the city
+func (trip *Trip) Visited(city *City) bool {
+	return strings.Contains(trip.Route, "-"+strconv.Itoa(city.Key)+"-")
+}
+
+// Descendants get all the neighbours of the city not visited before
+func (trip *Trip) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0, len(trip.Neighbours))
+	for i, val := range trip.Neighbours {
+		if trip.Visited(val) {
+			continue
+		}
+		tmp = append(tmp, &Trip{val, trip.Route + strconv.Itoa(val.Key) + "-", trip.TotCost + trip.Distances[i], trip.Destination})
+	}
+	return tmp
+}
+
+// Done is true when the destination is reached
+func (trip *Trip) Done() bool {
+	return trip.City == trip.Destination
+}
+
+// Cost returns the total distance travelled so far
+func (trip *Trip) Cost() float64 { return trip.TotCost }
+
+// Away returns the geo distance from the city to the destination
+func (trip *Trip) Away() float64 {
+	return trip.Distance(trip.Destination)
+}
+
+// Key returns the Route (cities visited so far)
+func (trip *Trip) Key() string {
+	return trip.Route
+}
+
+// String is the city name and the distance travelled so far
+func (trip *Trip) String() string {
+	return fmt.Sprintf("%-15s %8.2fkm", trip.Name, trip.TotCost)
+}
+
+// Drive is a state of a search for a route like Trip but its key is the city reached and not the route, so a route
+// reaching a city already reached is a duplicate and may go back through the cities it visited
+// On a big network (like Grid) searching with Trip has to go through far too many routes
+// Includes City, the city reached
+// TotCost is the total distance travelled so far
+// Destination is the goal
+type Drive struct {
+	*City
+	TotCost     float64
+	Destination *City
+}
+
+// StartDrive returns the drive from the city to the destination
+func (net *Network) StartDrive(from, to string) (*Drive, error) {
+	city, ok := net.Cities[from]
+	if !ok {
+		return nil, fmt.Errorf("unknown city %q", from)
+	}
+	dest, ok := net.Cities[to]
+	if !ok {
+		return nil, fmt.Errorf("unknown city %q", to)
+	}
+	return &Drive{city, 0, dest}, nil
+}
+
+// Descendants get all the neighbours of the city
+func (drive *Drive) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, len(drive.Neighbours))
+	for i, val := range drive.Neighbours {
+		tmp[i] = &Drive{val, drive.TotCost + drive.Distances[i], drive.Destination}
+	}
+	return tmp
+}
+
+// Done is true when the destination is reached
+func (drive *Drive) Done() bool {
+	return drive.City == drive.Destination
+}
+
+// Cost returns the total distance travelled so far
+func (drive *Drive) Cost() float64 { return drive.TotCost }
+
+// Away returns the geo distance from the city to the destination
+func (drive *Drive) Away() float64 {
+	return drive.Distance(drive.Destination)
+}
+
+// Key returns the key of the city reached
+func (drive *Drive) Key() string {
+	return strconv.Itoa(drive.City.Key)
+}
+
+// String is the city name and the distance travelled so far
+func (drive *Drive) String() string {
+	return fmt.Sprintf("%-15s %8.2fkm", drive.Name, drive.TotCost)
+}
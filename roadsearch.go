@@ -0,0 +1,220 @@
+// roadsearch.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Searches for a road trip on the city network of the roads package with the searches that give up the cheapest
+// route to expand fewer states: greedy best first, weighted A* and beam search
+// With -compare the route is searched with all of them and compared to BestCostAwaySearch (A*), with -all that is
+// done for every pair of cities
+// With -anytime anytime weighted A* prints every shorter route as it is found with how far it can still be above the
+// shortest
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/roads"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// variant is a search that can be chosen
+type variant struct {
+	name string
+	find func(src.SearchF) *search.Result
+}
+
+// variants returns the searches with the weight and width given, A* first
+func variants(weight float64, width int) []variant {
+	return []variant{
+		{"costaway", search.BestCostAway},
+		{"cost", search.BestCost},
+		{"breadth", search.BreadthFirst},
+		{"greedy", search.Greedy},
+		{fmt.Sprintf("weighted %g", weight), func(st src.SearchF) *search.Result { return search.Weighted(st, weight) }},
+		{fmt.Sprintf("beam %d", width), func(st src.SearchF) *search.Result { return search.Beam(st, width) }},
+	}
+}
+
+// route returns the cities of the route found
+func route(res *search.Result) string {
+	tmp := make([]string, len(res.Path))
+	for i, st := range res.Path {
+		tmp[i] = st.(*roads.Trip).Name
+	}
+	return strings.Join(tmp, " - ")
+}
+
+// compare searches for the route with all the searches and prints a table comparing them to A*
+func compare(start *roads.Trip, vars []variant) {
+	fmt.Printf("%-14s %10s %7s %6s %7s %9s %7s  %s\n", "Search", "Km", "Ratio", "Bound", "Steps", "Generated", "Pruned", "Route")
+	best := 0.0
+	for i, val := range vars {
+		res := val.find(start)
+		if res.Goal == nil {
+			fmt.Printf("%-14s %10s %7s %6s %7d %9d %7d  no route found\n", val.name, "-", "-", "-", res.Expanded, res.Generated, res.Pruned)
+			continue
+		}
+		if i == 0 {
+			best = res.Cost
+		}
+		bound := "-"
+		if res.Bound > 0 {
+			bound = fmt.Sprintf("%.2f", res.Bound)
+		}
+		fmt.Printf("%-14s %10.2f %7.3f %6s %7d %9d %7d  %s\n", val.name, res.Cost, res.Cost/best, bound, res.Expanded, res.Generated, res.Pruned, route(res))
+	}
+}
+
+// compareAll searches for the routes between all the pairs of cities with all the searches
+// For every search the routes found, those that are the cheapest, the mean and worst ratio to the cheapest
+// and the steps compared to A* are printed
+// The routes found by anytime weighted A* (with weight) are checked to get shorter, to be within their bounds and to
+// end with the cheapest
+// It returns false if a weighted A* or anytime route is more than its bound above the cheapest
+func compareAll(net *roads.Network, vars []variant, weight float64) bool {
+	type total struct {
+		found, cheapest, steps int
+		ratios, worst          float64
+	}
+	totals := make([]total, len(vars))
+	fine := true
+	improved := 0
+	names := net.Names()
+	for _, from := range names {
+		for _, to := range names {
+			if from == to {
+				continue
+			}
+			start, _ := net.Start(from, to)
+			best := 0.0
+			for i, val := range vars {
+				res := val.find(start)
+				tot := &totals[i]
+				tot.steps += res.Expanded
+				if res.Goal == nil {
+					continue
+				}
+				if i == 0 {
+					best = res.Cost
+				}
+				ratio := res.Cost / best
+				tot.found++
+				tot.ratios += ratio
+				tot.worst = math.Max(tot.worst, ratio)
+				if ratio < 1+1e-9 {
+					tot.cheapest++
+				}
+				if res.Bound > 0 && ratio > res.Bound+1e-9 {
+					fmt.Printf("%s from %s to %s is %.3f times the cheapest, more than its bound %.2f\n", val.name, from, to, ratio, res.Bound)
+					fine = false
+				}
+			}
+			last := math.Inf(1)
+			results, errc := search.Anytime(context.Background(), start, weight, search.Limits{})
+			for res := range results {
+				ratio := res.Cost / best
+				improved++
+				if res.Cost >= last || ratio > res.Bound+1e-9 || res.Bound == 1 && ratio > 1+1e-9 {
+					fmt.Printf("anytime from %s to %s found %.2fkm with bound %.3f after %.2fkm, the cheapest is %.2fkm\n", from, to, res.Cost, res.Bound, last, best)
+					fine = false
+				}
+				last = res.Cost
+			}
+			if err := <-errc; err != nil || last != best {
+				fmt.Printf("anytime from %s to %s ended with %.2fkm (%v), the cheapest is %.2fkm\n", from, to, last, err, best)
+				fine = false
+			}
+		}
+	}
+	pairs := len(names) * (len(names) - 1)
+	fmt.Printf("%d pairs of cities\n", pairs)
+	fmt.Printf("%-14s %7s %9s %10s %10s %9s %9s\n", "Search", "Found", "Cheapest", "Mean ratio", "Worst", "Steps", "vs A*")
+	for i, val := range vars {
+		tot := totals[i]
+		mean := 0.0
+		if tot.found > 0 {
+			mean = tot.ratios / float64(tot.found)
+		}
+		fmt.Printf("%-14s %7d %9d %10.3f %10.3f %9d %8.1f%%\n", val.name, tot.found, tot.cheapest, mean, tot.worst, tot.steps, 100*float64(tot.steps)/float64(totals[0].steps))
+	}
+	fmt.Printf("Anytime weighted A* %g found %d routes on the way to the cheapest\n", weight, improved)
+	return fine
+}
+
+// improve prints the routes found by anytime weighted A* until the shortest is proven
+func improve(start *roads.Trip, weight float64) {
+	fmt.Printf("%-4s %10s %6s %7s %9s %12s  %s\n", "", "Km", "Bound", "Steps", "Generated", "Time", "Route")
+	i := 0
+	results, errc := search.Anytime(context.Background(), start, weight, search.Limits{})
+	for res := range results {
+		i++
+		fmt.Printf("%-4d %10.2f %6.3f %7d %9d %12v  %s\n", i, res.Cost, res.Bound, res.Expanded, res.Generated, res.Time, route(res))
+	}
+	switch err := <-errc; {
+	case i == 0:
+		fmt.Println("No route found")
+	case err == nil:
+		fmt.Println("The last route is the shortest")
+	default:
+		fmt.Println(err)
+	}
+}
+
+func main() {
+	from := flag.String("from", "Johannesburg", "city to start from")
+	to := flag.String("to", "Cape Town", "city to go to")
+	name := flag.String("search", "costaway", "costaway, cost, breadth, greedy, weighted or beam")
+	weight := flag.Float64("weight", 2, "how many times Away is counted by weighted A*")
+	width := flag.Int("beam", 3, "the number of states of every depth kept by beam search")
+	cmp := flag.Bool("compare", false, "search the route with all the searches and compare them")
+	all := flag.Bool("all", false, "compare the searches on the routes between all the pairs of cities")
+	anytime := flag.Bool("anytime", false, "print every shorter route found by anytime weighted A* (with -weight)")
+	flag.Parse()
+
+	net := roads.SouthAfrica()
+	vars := variants(*weight, *width)
+	if *all {
+		if !compareAll(net, vars, *weight) {
+			os.Exit(1)
+		}
+		return
+	}
+	start, err := net.Start(*from, *to)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if *cmp {
+		compare(start, vars)
+		return
+	}
+	if *anytime {
+		improve(start, *weight)
+		return
+	}
+	var find func(src.SearchF) *search.Result
+	for _, val := range vars {
+		if strings.Fields(val.name)[0] == *name {
+			find = val.find
+		}
+	}
+	if find == nil {
+		fmt.Fprintf(os.Stderr, "Unknown search %q\n", *name)
+		os.Exit(2)
+	}
+	res := find(start)
+	fmt.Println(res)
+	if res.Goal == nil {
+		fmt.Println("No route found")
+		os.Exit(1)
+	}
+	fmt.Printf("%.2fkm: %s\n", res.Cost, route(res))
+	if res.Bound > 0 {
+		fmt.Printf("At most %.2f times the shortest route\n", res.Bound)
+	}
+}
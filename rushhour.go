@@ -0,0 +1,113 @@
+// rushhour.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Implements A* (BestCostAwayContext of the search package, which drops boards already expanded) on Rush Hour
+// Away is the number of vehicles between the red car and the exit plus one for the red car
+// A level is 36 characters row by row (-level), or a file (-file) has one level per line, and with -random
+// a random level that takes at least -least moves is generated
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hduplooy/gosearch-test/rushhour"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// solve searches for the fewest moves and returns the result of the search and the moves (nil if there is no solution)
+func solve(level string, show bool) (*search.Result, []string, error) {
+	start, err := rushhour.Parse(level)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, _ := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+	if res.Goal == nil {
+		return res, nil, nil
+	}
+	if show {
+		for _, st := range res.Path {
+			fmt.Printf("%s\n%v", st.(*rushhour.State).Move(), st)
+		}
+		fmt.Println()
+	}
+	return res, rushhour.Path(res.History(), res.Goal), nil
+}
+
+// readLevels reads the levels from a file, the first field of 36 characters on every line is used
+func readLevels(name string) ([]string, error) {
+	fl, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fl.Close()
+	tmp := make([]string, 0)
+	scan := bufio.NewScanner(fl)
+	for scan.Scan() {
+		for _, field := range strings.Fields(scan.Text()) {
+			if len(field) == rushhour.Size*rushhour.Size {
+				tmp = append(tmp, field)
+				break
+			}
+		}
+	}
+	return tmp, scan.Err()
+}
+
+func main() {
+	level := flag.String("level", "GBBoLoGHIoLMGHIAAMCCCKoMooJKDDEEJFFo", "level as 36 characters row by row (o empty, x wall, A the red car)")
+	file := flag.String("file", "", "file with one level per line")
+	random := flag.Bool("random", false, "solve a random level")
+	vehicles := flag.Int("vehicles", 12, "number of vehicles besides the red car for -random")
+	walls := flag.Int("walls", 0, "number of walls for -random")
+	least := flag.Int("least", 10, "least moves the random level must take")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed")
+	show := flag.Bool("print", false, "print the board after every move")
+	flag.Parse()
+
+	levels := []string{*level}
+	switch {
+	case *file != "":
+		var err error
+		if levels, err = readLevels(*file); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	case *random:
+		lvl, moves := rushhour.Generate(*vehicles, *walls, *least, 1000, rand.New(rand.NewSource(*seed)))
+		if lvl == "" {
+			fmt.Fprintln(os.Stderr, "No random level found that takes long enough, try fewer -least moves")
+			os.Exit(1)
+		}
+		fmt.Printf("Generated %s taking %d moves\n", lvl, moves)
+		levels = []string{lvl}
+	}
+	fine := true
+	for _, lvl := range levels {
+		start, err := rushhour.Parse(lvl)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Invalid level:", err)
+			os.Exit(2)
+		}
+		if !*show {
+			fmt.Print(start)
+		}
+		res, moves, _ := solve(lvl, *show)
+		fmt.Println(res)
+		if moves == nil {
+			fmt.Println("No solution exists")
+			fine = false
+			continue
+		}
+		fmt.Printf("Solved in %d moves: %s\n\n", len(moves), strings.Join(moves, " "))
+	}
+	if !fine {
+		os.Exit(1)
+	}
+}
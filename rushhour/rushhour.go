@@ -0,0 +1,296 @@
+// rushhour.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Package rushhour implements the Rush Hour puzzle as states for hduplooy/gosearch
+// Cars and trucks on a 6X6 board can only slide forwards and backwards, the red car (A) must get out through
+// the exit on the right of its row. Sliding a vehicle any number of squares is one move
+// A level is given as 36 characters row by row: o (or .) is empty, x a wall and every vehicle has its own letter
+package rushhour
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// Size is the number of rows and columns of the board
+const Size = 6
+
+// Vehicle is a car (length 2) or truck (length 3)
+// Name is its letter, Across is true if it moves left and right and Fixed is the row (or column if it moves
+// up and down) it can not leave
+type Vehicle struct {
+	Name   byte
+	Across bool
+	Length int
+	Fixed  int
+}
+
+// Board holds everything shared by all the states of a level
+// Vehicles has the vehicles with the red car first and walls marks the squares (row*Size+col) with walls
+type Board struct {
+	Vehicles []Vehicle
+	walls    [Size * Size]bool
+}
+
+// Parse reads a level and returns its start state
+func Parse(level string) (*State, error) {
+	level = strings.TrimSpace(level)
+	if len(level) != Size*Size {
+		return nil, fmt.Errorf("a level must have %d squares but has %d", Size*Size, len(level))
+	}
+	brd := &Board{}
+	// For every letter the squares it is on
+	squares := make(map[byte][]int)
+	names := make([]byte, 0)
+	for i := 0; i < len(level); i++ {
+		ch := level[i]
+		switch {
+		case ch == 'o' || ch == '.':
+		case ch == 'x':
+			brd.walls[i] = true
+		case ch >= 'A' && ch <= 'Z':
+			if squares[ch] == nil {
+				names = append(names, ch)
+			}
+			squares[ch] = append(squares[ch], i)
+		default:
+			return nil, fmt.Errorf("invalid square %q", ch)
+		}
+	}
+	if squares['A'] == nil {
+		return nil, errors.New("there is no red car (A)")
+	}
+	// The red car is first
+	for i, name := range names {
+		if name == 'A' {
+			names[0], names[i] = names[i], names[0]
+		}
+	}
+	pos := make([]byte, len(names))
+	for i, name := range names {
+		sq := squares[name]
+		if len(sq) < 2 || len(sq) > 3 {
+			return nil, fmt.Errorf("vehicle %c must have 2 or 3 squares", name)
+		}
+		veh := Vehicle{Name: name, Length: len(sq)}
+		switch {
+		case sq[1]-sq[0] == 1 && sq[0]/Size == sq[len(sq)-1]/Size:
+			veh.Across = true
+			veh.Fixed = sq[0] / Size
+			pos[i] = byte(sq[0] % Size)
+		case sq[1]-sq[0] == Size:
+			veh.Fixed = sq[0] % Size
+			pos[i] = byte(sq[0] / Size)
+		default:
+			return nil, fmt.Errorf("vehicle %c is not in a straight line", name)
+		}
+		for j := 1; j < len(sq); j++ {
+			if sq[j]-sq[j-1] != sq[1]-sq[0] {
+				return nil, fmt.Errorf("vehicle %c is not in a straight line", name)
+			}
+		}
+		brd.Vehicles = append(brd.Vehicles, veh)
+	}
+	if !brd.Vehicles[0].Across {
+		return nil, errors.New("the red car must move across")
+	}
+	return &State{brd, pos, 0, ""}, nil
+}
+
+// square returns the square of part i of vehicle v at position pos
+func (veh *Vehicle) square(pos, i int) int {
+	if veh.Across {
+		return veh.Fixed*Size + pos + i
+	}
+	return (pos+i)*Size + veh.Fixed
+}
+
+// State has for every vehicle its position along its row or column (the left or top square)
+// Moves is the number of moves made and move the last one (like B+2 for vehicle B sliding 2 squares right or down)
+type State struct {
+	*Board
+	Pos   []byte
+	Moves int
+	move  string
+}
+
+// occupied marks the squares taken by walls and vehicles
+func (st *State) occupied() [Size * Size]bool {
+	tmp := st.walls
+	for i := range st.Vehicles {
+		veh := &st.Vehicles[i]
+		for j := 0; j < veh.Length; j++ {
+			tmp[veh.square(int(st.Pos[i]), j)] = true
+		}
+	}
+	return tmp
+}
+
+// Descendants slides every vehicle every distance it can go both ways
+func (st *State) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0)
+	occ := st.occupied()
+	for i := range st.Vehicles {
+		veh := &st.Vehicles[i]
+		pos := int(st.Pos[i])
+		// Backwards while the square before it is free
+		for p := pos - 1; p >= 0 && !occ[veh.square(p, 0)]; p-- {
+			tmp = append(tmp, st.slide(i, p))
+		}
+		// Forwards while the square after it is free
+		for p := pos + 1; p+veh.Length <= Size && !occ[veh.square(p, veh.Length-1)]; p++ {
+			tmp = append(tmp, st.slide(i, p))
+		}
+	}
+	return tmp
+}
+
+// slide returns the state with vehicle i moved to p
+func (st *State) slide(i, p int) *State {
+	pos := make([]byte, len(st.Pos))
+	copy(pos, st.Pos)
+	pos[i] = byte(p)
+	return &State{st.Board, pos, st.Moves + 1, fmt.Sprintf("%c%+d", st.Vehicles[i].Name, p-int(st.Pos[i]))}
+}
+
+// Done is true if the red car is at the exit
+func (st *State) Done() bool {
+	return int(st.Pos[0])+st.Vehicles[0].Length == Size
+}
+
+// Cost is the number of moves
+func (st *State) Cost() float64 { return float64(st.Moves) }
+
+// Away is the number of vehicles between the red car and the exit plus one for the red car itself,
+// each of them has to move at least once
+func (st *State) Away() float64 {
+	if st.Done() {
+		return 0
+	}
+	red := &st.Vehicles[0]
+	blocking := make(map[int]bool)
+	for i := range st.Vehicles[1:] {
+		veh := &st.Vehicles[i+1]
+		for j := 0; j < veh.Length; j++ {
+			sq := veh.square(int(st.Pos[i+1]), j)
+			if sq/Size == red.Fixed && sq%Size >= int(st.Pos[0])+red.Length {
+				blocking[i] = true
+			}
+		}
+	}
+	return float64(len(blocking) + 1)
+}
+
+// Key is the positions of the vehicles
+func (st *State) Key() string {
+	return string(st.Pos)
+}
+
+// Move is the last move
+func (st *State) Move() string { return st.move }
+
+// Level returns the level string of the state
+func (st *State) Level() string {
+	tmp := []byte(strings.Repeat("o", Size*Size))
+	for i := range tmp {
+		if st.walls[i] {
+			tmp[i] = 'x'
+		}
+	}
+	for i := range st.Vehicles {
+		veh := &st.Vehicles[i]
+		for j := 0; j < veh.Length; j++ {
+			tmp[veh.square(int(st.Pos[i]), j)] = veh.Name
+		}
+	}
+	return string(tmp)
+}
+
+// String draws the board with the exit marked
+func (st *State) String() string {
+	level := st.Level()
+	var buf strings.Builder
+	for r := 0; r < Size; r++ {
+		buf.WriteString(level[r*Size : (r+1)*Size])
+		if r == st.Vehicles[0].Fixed {
+			buf.WriteString(" <")
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+// Path returns the moves from the start to the goal given the history and answer of a search
+func Path(hist []src.SearchF, ans src.SearchF) []string {
+	tmp := make([]string, 0, len(hist)+1)
+	for i := len(hist) - 1; i >= 0; i-- {
+		if move := hist[i].(*State).move; move != "" {
+			tmp = append(tmp, move)
+		}
+	}
+	if move := ans.(*State).move; move != "" {
+		tmp = append(tmp, move)
+	}
+	return tmp
+}
+
+// Generate returns a random level that can be solved in at least least moves with the number of moves it takes
+// It gives up after tries levels and returns an empty level
+func Generate(vehicles, walls, least, tries int, rnd *rand.Rand) (string, int) {
+	for ; tries > 0; tries-- {
+		level := Random(vehicles, walls, rnd)
+		start, _ := Parse(level)
+		res, _ := search.BreadthFirstContext(context.Background(), start, search.Limits{})
+		if res.Goal != nil && res.Cost >= float64(least) {
+			return level, int(res.Cost)
+		}
+	}
+	return "", 0
+}
+
+// Random returns a random level with the red car in the third row and up to vehicles other vehicles and walls walls
+// It is not checked that the level can be solved
+func Random(vehicles, walls int, rnd *rand.Rand) string {
+	level := []byte(strings.Repeat("o", Size*Size))
+	row := 2 * Size
+	col := rnd.Intn(Size - 2)
+	level[row+col], level[row+col+1] = 'A', 'A'
+	name := byte('B')
+	for tries := 0; tries < 100 && int(name-'B') < vehicles; tries++ {
+		length, across := 2, rnd.Intn(2) == 0
+		if rnd.Intn(4) == 0 {
+			length = 3
+		}
+		// Nothing else may move across in the row of the red car or it can never get out
+		r, c, step := rnd.Intn(Size), rnd.Intn(Size-length+1), 1
+		if across && r == 2 {
+			continue
+		}
+		if !across {
+			r, c, step = c, r, Size
+		}
+		fine := true
+		for i := 0; i < length; i++ {
+			fine = fine && level[r*Size+c+i*step] == 'o'
+		}
+		if !fine {
+			continue
+		}
+		for i := 0; i < length; i++ {
+			level[r*Size+c+i*step] = name
+		}
+		name++
+	}
+	for i := 0; i < walls; i++ {
+		if sq := rnd.Intn(Size * Size); level[sq] == 'o' && sq/Size != 2 {
+			level[sq] = 'x'
+		}
+	}
+	return string(level)
+}
@@ -0,0 +1,83 @@
+// rushhour_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks the fewest moves found for levels with known solutions and the levels generated
+package rushhour_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/rushhour"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// Levels with the fewest moves to solve them (-1 if they can not be solved)
+var instances = []struct {
+	level string
+	moves int
+}{
+	{"ooooooooooooAAoooooooooooooooooooooo", 1},
+	{"BBoooxoooCoooAACoooooooooooooooooooo", 2},
+	{"ooooooooooooAAooxooooooooooooooooooo", -1},
+	{"IBBxooIooLDDJAALooJoKEEMFFKooMGGHHHM", 60},
+	{"GBBoLoGHIoLMGHIAAMCCCKoMooJKDDEEJFFo", 51},
+}
+
+// solve returns the fewest moves for the level (-1 if it can not be solved)
+func solve(t *testing.T, level string) int {
+	t.Helper()
+	start, err := rushhour.Parse(level)
+	if err != nil {
+		t.Fatalf("%s: %v", level, err)
+	}
+	res, _ := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+	if res.Goal == nil {
+		return -1
+	}
+	moves := rushhour.Path(res.History(), res.Goal)
+	if len(moves) != int(res.Cost) {
+		t.Errorf("%s: %d moves in the path but the cost is %g", level, len(moves), res.Cost)
+	}
+	return len(moves)
+}
+
+func TestFewestMoves(t *testing.T) {
+	for _, inst := range instances {
+		if got := solve(t, inst.level); got != inst.moves {
+			t.Errorf("%s: %d moves, expected %d", inst.level, got, inst.moves)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 5; i++ {
+		level, moves := rushhour.Generate(12, 1, 8, 1000, rnd)
+		if level == "" {
+			t.Fatal("no level generated")
+		}
+		if moves < 8 {
+			t.Errorf("%s: generated taking %d moves, at least 8 were asked for", level, moves)
+		}
+		if got := solve(t, level); got != moves {
+			t.Errorf("%s: generated taking %d moves but A* takes %d", level, moves, got)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, level := range []string{
+		"oooooooooooooooooooooooooooooooooooo",
+		"ooooooooooooAAooooooooooooooooooooo",
+		"ooooooooooooAAoBoooooooooooooooooooo",
+		"ooooooooooooAAoooCooooooCoooooooooCo",
+		"AooooooAoooooooooooooooooooooooooooo",
+		"ooooooooooooAAoo?ooooooooooooooooooo",
+	} {
+		if _, err := rushhour.Parse(level); err == nil {
+			t.Errorf("%s: parsed without an error", level)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// anytime.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Anytime weighted A* finds a first goal quickly by counting Away w times, and then keeps on searching for cheaper
+// goals until it has proven that the last one is the cheapest (if Away never overestimates)
+// Every cheaper goal found is sent on a channel with the bound on how far it can be above the cheapest, the time
+// spent waiting for the channel to be read is not counted in the Time of the results
+package search
+
+import (
+	"container/heap"
+	"context"
+	"math"
+	"time"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Anytime does anytime weighted A* and returns the channel the improving results are sent on
+// The states are expanded on the lowest Cost plus w times Away first, like WeightedContext, but the search does not
+// stop at the first goal. States with Cost plus Away not below the cost of the best goal so far are dropped and a
+// state is expanded again if it is reached with a lower Cost than before
+// Every result sent has a lower cost than the one before and its Bound is that cost divided by the lowest Cost plus
+// Away still on the frontier (which the cheapest goal can not be below), a goal is never sent twice
+// The results channel is closed when the search is done and then the error is sent on the second channel, it is nil
+// if the last result sent was proven to be the cheapest goal (even if its Bound is above 1) and otherwise an *Error
+// saying why the search stopped
+// Results are only sent while the channel is read, the search waits for it
+func Anytime(ctx context.Context, start src.SearchF, w float64, limits Limits) (<-chan *Result, <-chan error) {
+	out := make(chan *Result)
+	errc := make(chan error, 1)
+	go func() {
+		err := anytime(ctx, start, w, limits, out)
+		close(out)
+		errc <- err
+		close(errc)
+	}()
+	return out, errc
+}
+
+// anytime is the search loop of Anytime, it returns nil if the last goal sent is the cheapest
+func anytime(ctx context.Context, start src.SearchF, w float64, limits Limits, out chan<- *Result) error {
+	tm := time.Now()
+	obs := limits.Observer
+	var stats Stats
+	var open nodeHeap
+	seq := 0
+	push := func(n *node) {
+		seq++
+		n.seq = seq
+		n.prio = n.state.Cost() + w*n.state.Away()
+		heap.Push(&open, n)
+		stats.Memory += StateSize + int64(len(n.key))
+		if len(open) > stats.PeakFrontier {
+			stats.PeakFrontier = len(open)
+		}
+	}
+	// best is the lowest cost every key was reached with, expanded has the keys expanded
+	best := make(map[string]float64)
+	expanded := make(map[string]struct{})
+	key := start.Key()
+	best[key] = start.Cost()
+	push(&node{state: start, key: key})
+	var goal *node
+	var waited time.Duration
+	// stop returns the error for the reason the search stopped
+	stop := func(reason Reason, err error) error {
+		stats.Frontier, stats.Closed = len(open), len(expanded)
+		return &Error{reason, err, stats}
+	}
+	// send sends the result with goal and the bound, it returns false if the search was stopped while waiting
+	send := func(bound float64) bool {
+		stats.Frontier, stats.Closed = len(open), len(expanded)
+		res := &Result{Goal: goal.state, Cost: goal.state.Cost(), Bound: bound, Stats: stats, Time: time.Since(tm) - waited}
+		for p := goal; p != nil; p = p.parent {
+			res.Path = append(res.Path, p.state)
+		}
+		for i, j := 0, len(res.Path)-1; i < j; i, j = i+1, j-1 {
+			res.Path[i], res.Path[j] = res.Path[j], res.Path[i]
+		}
+		defer func(tm time.Time) { waited += time.Since(tm) }(time.Now())
+		select {
+		case out <- res:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	// lower is the lowest Cost plus Away on the frontier, the cheapest goal can not be below it
+	lower := func() float64 {
+		tmp := math.Inf(1)
+		for _, n := range open {
+			tmp = math.Min(tmp, n.state.Cost()+n.state.Away())
+		}
+		return tmp
+	}
+	for len(open) > 0 {
+		if stats.Expanded%CheckEvery == 0 {
+			select {
+			case <-ctx.Done():
+				return stop(Cancelled, ctx.Err())
+			default:
+			}
+		}
+		cur := heap.Pop(&open).(*node)
+		stats.Memory -= StateSize + int64(len(cur.key))
+		// Drop states reached more cheaply since and those that can not lead to a cheaper goal
+		if cur.state.Cost() > best[cur.key] || goal != nil && cur.state.Cost()+cur.state.Away() >= goal.state.Cost() {
+			stats.Duplicates++
+			if obs != nil {
+				obs.OnDuplicate(cur.event(0))
+			}
+			continue
+		}
+		if limits.MaxExpansions > 0 && stats.Expanded >= limits.MaxExpansions {
+			return stop(ExpansionsExceeded, nil)
+		}
+		stats.Expanded++
+		expanded[cur.key] = struct{}{}
+		if len(expanded) > stats.PeakClosed {
+			stats.PeakClosed = len(expanded)
+		}
+		if obs != nil {
+			obs.OnExpand(cur.event(stats.Expanded))
+		}
+		if cur.state.Done() {
+			if obs != nil {
+				obs.OnGoal(cur.event(stats.Expanded))
+			}
+			goal = cur
+			bound := 1.0
+			if lb := lower(); lb < goal.state.Cost() {
+				bound = goal.state.Cost() / lb
+			}
+			if !send(bound) {
+				return stop(Cancelled, ctx.Err())
+			}
+			if bound == 1 {
+				return nil
+			}
+			continue
+		}
+		for _, desc := range cur.state.Descendants() {
+			stats.Generated++
+			n := &node{state: desc, key: desc.Key(), parent: cur, depth: cur.depth + 1}
+			if obs != nil {
+				obs.OnGenerate(n.event(0))
+			}
+			g := desc.Cost()
+			if old, ok := best[n.key]; ok && old <= g || goal != nil && g+desc.Away() >= goal.state.Cost() {
+				stats.Duplicates++
+				if obs != nil {
+					obs.OnDuplicate(n.event(0))
+				}
+				continue
+			}
+			best[n.key] = g
+			push(n)
+		}
+		if limits.MaxMemory > 0 && stats.Memory+int64(len(expanded))*StateSize > limits.MaxMemory {
+			return stop(MemoryExceeded, nil)
+		}
+	}
+	// Nothing is left that can lead to a cheaper goal, so the last one sent is the cheapest
+	if goal == nil {
+		return stop(NoSolution, nil)
+	}
+	return nil
+}
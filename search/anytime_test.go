@@ -0,0 +1,81 @@
+// anytime_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks that anytime weighted A* sends ever shorter routes within their bounds and ends with the shortest
+package search_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/roads"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+func TestAnytimeImproves(t *testing.T) {
+	net := roads.SouthAfrica()
+	names := net.Names()
+	for _, w := range []float64{1, 1.5, 2, 5} {
+		for _, from := range names {
+			for _, to := range names {
+				if from == to {
+					continue
+				}
+				name := fmt.Sprintf("%s to %s with weight %g", from, to, w)
+				start, err := net.Start(from, to)
+				if err != nil {
+					t.Fatal(err)
+				}
+				base, err := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				results, errc := search.Anytime(context.Background(), start, w, search.Limits{})
+				var last *search.Result
+				for res := range results {
+					if last != nil && res.Cost >= last.Cost {
+						t.Errorf("%s: %.2fkm sent after %.2fkm", name, res.Cost, last.Cost)
+					}
+					if res.Bound < 1 || res.Cost > res.Bound*base.Cost+1e-6 {
+						t.Errorf("%s: %.2fkm is not within its bound %.3f of the shortest %.2fkm", name, res.Cost, res.Bound, base.Cost)
+					}
+					last = res
+				}
+				if err := <-errc; err != nil || last == nil || last.Cost != base.Cost {
+					t.Errorf("%s: ended with %v (error %v), the shortest is %.2fkm", name, last, err, base.Cost)
+				}
+			}
+		}
+	}
+}
+
+func TestAnytimeStops(t *testing.T) {
+	start, err := roads.Grid(30, 30, 1).Start("R0C0", "R29C29")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		limits search.Limits
+		reason search.Reason
+	}{
+		{"cancelled", ctx, search.Limits{}, search.Cancelled},
+		{"expansions", context.Background(), search.Limits{MaxExpansions: 100}, search.ExpansionsExceeded},
+		{"memory", context.Background(), search.Limits{MaxMemory: 100 * search.StateSize}, search.MemoryExceeded},
+	}
+	for _, test := range tests {
+		results, errc := search.Anytime(test.ctx, start, 2, test.limits)
+		for range results {
+		}
+		err := <-errc
+		var serr *search.Error
+		if !errors.As(err, &serr) || serr.Reason != test.reason {
+			t.Errorf("%s: got error %v, expected %v", test.name, err, test.reason)
+		}
+	}
+}
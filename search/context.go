@@ -0,0 +1,302 @@
+// context.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// The searches of hduplooy/gosearch run until they find a goal or run out of states, which can take forever on a
+// big state space. These search in the same order but with their own loop, so that they stop when the context is
+// cancelled (or its deadline passes), after a maximum number of expansions or when the states kept use more than a
+// memory budget. A state is closed when it is expanded and a state with the same key reached again is dropped,
+// where hduplooy/gosearch searches it again, so on state spaces with more than one way to a state these take far
+// fewer steps
+// They return why they stopped as an *Error together with the result so far (with the statistics of how far they got)
+package search
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"time"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Limits bounds a search, a zero value means no limit
+// MaxExpansions is the most states that may be expanded
+// MaxMemory is the most bytes the states kept (on the frontier and closed) may use, it is an estimate counting
+// StateSize bytes for every state plus the length of its key
+// Observer is not a limit but is told about every step of the search if it is not nil
+type Limits struct {
+	MaxExpansions int
+	MaxMemory     int64
+	Observer      Observer
+}
+
+// StateSize is the estimated size of a state and the bookkeeping for it used for MaxMemory
+const StateSize = 128
+
+// CheckEvery is how many expansions there are between checks of the context, the typed searches use it too
+const CheckEvery = 256
+
+// Stats are the statistics of a search, also when it stopped without a solution
+// Expanded is the number of states expanded (the steps of hduplooy/gosearch)
+// Generated is the number of descendants generated
+// Duplicates is the number of states dropped because a state with the same key was already expanded
+// Frontier and Closed are the number of states waiting to be expanded and already expanded when it stopped
+// and PeakFrontier and PeakClosed the most there were at any time
+// Memory is the estimated bytes used by the states kept
+// Iterations is the number of depth first searches done by the iterative deepening searches (0 for the others)
+// Pruned is the number of states dropped from the frontier by beam search
+// Messages is the number of states sent from one worker to another by parallel A* (0 for the others)
+type Stats struct {
+	Expanded     int
+	Generated    int
+	Duplicates   int
+	Frontier     int
+	Closed       int
+	PeakFrontier int
+	PeakClosed   int
+	Memory       int64
+	Iterations   int
+	Pruned       int
+	Messages     int
+}
+
+// Reason says why a search stopped without a solution
+type Reason int
+
+// The reasons a search can stop without a solution
+const (
+	NoSolution Reason = iota
+	Cancelled
+	ExpansionsExceeded
+	MemoryExceeded
+)
+
+// String describes the reason
+func (r Reason) String() string {
+	switch r {
+	case NoSolution:
+		return "no solution"
+	case Cancelled:
+		return "cancelled"
+	case ExpansionsExceeded:
+		return "expansion budget exceeded"
+	case MemoryExceeded:
+		return "memory budget exceeded"
+	}
+	return fmt.Sprintf("reason %d", int(r))
+}
+
+// Error is returned when a search stops without a solution
+// Err is the error of the context if it was cancelled and Stats has how far the search got (the same as
+// those of the result returned with it)
+type Error struct {
+	Reason Reason
+	Err    error
+	Stats  Stats
+}
+
+// Error describes why the search stopped
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("search %s after %d steps: %v", e.Reason, e.Stats.Expanded, e.Err)
+	}
+	return fmt.Sprintf("search %s after %d steps", e.Reason, e.Stats.Expanded)
+}
+
+// Unwrap returns the error of the context so that errors.Is(err, context.DeadlineExceeded) works
+func (e *Error) Unwrap() error { return e.Err }
+
+// node is a state on the frontier with the node it was generated from and its depth
+type node struct {
+	state  src.SearchF
+	key    string
+	parent *node
+	depth  int
+	prio   float64
+	seq    int
+}
+
+// frontier holds the states still to be expanded
+type frontier interface {
+	push(*node)
+	pop() *node
+	size() int
+}
+
+// stack is the frontier of depth first search, the last descendant is expanded first
+type stack []*node
+
+func (s *stack) push(n *node) { *s = append(*s, n) }
+func (s *stack) size() int    { return len(*s) }
+func (s *stack) pop() *node {
+	n := (*s)[len(*s)-1]
+	*s = (*s)[:len(*s)-1]
+	return n
+}
+
+// queue is the frontier of breadth first search
+type queue struct {
+	nodes []*node
+	first int
+}
+
+func (q *queue) push(n *node) { q.nodes = append(q.nodes, n) }
+func (q *queue) size() int    { return len(q.nodes) - q.first }
+func (q *queue) pop() *node {
+	n := q.nodes[q.first]
+	q.nodes[q.first] = nil
+	q.first++
+	// Move what is left to the front once the front half is used up
+	if q.first > len(q.nodes)/2 && q.first > 1024 {
+		q.nodes = append(q.nodes[:0], q.nodes[q.first:]...)
+		q.first = 0
+	}
+	return n
+}
+
+// nodeHeap orders the nodes on prio and then on the order they were generated
+type nodeHeap []*node
+
+func (h nodeHeap) Len() int { return len(h) }
+func (h nodeHeap) Less(i, j int) bool {
+	if h[i].prio != h[j].prio {
+		return h[i].prio < h[j].prio
+	}
+	return h[i].seq < h[j].seq
+}
+func (h nodeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *nodeHeap) Push(x interface{}) { *h = append(*h, x.(*node)) }
+func (h *nodeHeap) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return n
+}
+
+// priority is the frontier of the best first searches, prio gives the priority of a state (lowest first)
+type priority struct {
+	nodes nodeHeap
+	prio  func(src.SearchF) float64
+	seq   int
+}
+
+func (p *priority) size() int { return len(p.nodes) }
+func (p *priority) pop() *node {
+	return heap.Pop(&p.nodes).(*node)
+}
+func (p *priority) push(n *node) {
+	p.seq++
+	n.seq = p.seq
+	n.prio = p.prio(n.state)
+	heap.Push(&p.nodes, n)
+}
+
+// run is the search loop shared by all the searches, only the frontier differs
+// A state is closed when it is expanded, states with a key already closed are skipped
+// The search is recorded in res, which is passed in so that a frontier can be made with its Stats
+func run(ctx context.Context, start src.SearchF, limits Limits, res *Result, open frontier) (*Result, error) {
+	tm := time.Now()
+	obs := limits.Observer
+	stats := &res.Stats
+	closed := make(map[string]struct{})
+	key := start.Key()
+	open.push(&node{state: start, key: key})
+	stats.Memory = StateSize + int64(len(key))
+	// finish fills in the rest of the result, with the goal found in n if it is not nil
+	finish := func(n *node, reason Reason, err error) (*Result, error) {
+		stats.Frontier, stats.Closed, stats.PeakClosed = open.size(), len(closed), len(closed)
+		res.Time = time.Since(tm)
+		if n != nil {
+			res.Goal, res.Cost = n.state, n.state.Cost()
+			for p := n; p != nil; p = p.parent {
+				res.Path = append(res.Path, p.state)
+			}
+			// The path was collected from the goal back to the start
+			for i, j := 0, len(res.Path)-1; i < j; i, j = i+1, j-1 {
+				res.Path[i], res.Path[j] = res.Path[j], res.Path[i]
+			}
+			return res, nil
+		}
+		return res, &Error{reason, err, res.Stats}
+	}
+	for open.size() > 0 {
+		if stats.Expanded%CheckEvery == 0 {
+			select {
+			case <-ctx.Done():
+				return finish(nil, Cancelled, ctx.Err())
+			default:
+			}
+		}
+		if open.size() > stats.PeakFrontier {
+			stats.PeakFrontier = open.size()
+		}
+		cur := open.pop()
+		if _, ok := closed[cur.key]; ok {
+			// A duplicate that was on the frontier more than once
+			stats.Duplicates++
+			stats.Memory -= StateSize + int64(len(cur.key))
+			if obs != nil {
+				obs.OnDuplicate(cur.event(0))
+			}
+			continue
+		}
+		if limits.MaxExpansions > 0 && stats.Expanded >= limits.MaxExpansions {
+			return finish(nil, ExpansionsExceeded, nil)
+		}
+		closed[cur.key] = struct{}{}
+		stats.Expanded++
+		if obs != nil {
+			obs.OnExpand(cur.event(stats.Expanded))
+		}
+		if cur.state.Done() {
+			if obs != nil {
+				obs.OnGoal(cur.event(stats.Expanded))
+			}
+			return finish(cur, NoSolution, nil)
+		}
+		for _, desc := range cur.state.Descendants() {
+			stats.Generated++
+			n := &node{state: desc, key: desc.Key(), parent: cur, depth: cur.depth + 1}
+			if obs != nil {
+				obs.OnGenerate(n.event(0))
+			}
+			if _, ok := closed[n.key]; ok {
+				stats.Duplicates++
+				if obs != nil {
+					obs.OnDuplicate(n.event(0))
+				}
+				continue
+			}
+			stats.Memory += StateSize + int64(len(n.key))
+			open.push(n)
+		}
+		if limits.MaxMemory > 0 && stats.Memory > limits.MaxMemory {
+			return finish(nil, MemoryExceeded, nil)
+		}
+	}
+	return finish(nil, NoSolution, nil)
+}
+
+// DepthFirstContext is depth first search like DepthFirstSearch of hduplooy/gosearch that can be stopped
+// If there is no goal the error is an *Error saying why, the result is returned either way
+func DepthFirstContext(ctx context.Context, start src.SearchF, limits Limits) (*Result, error) {
+	return run(ctx, start, limits, &Result{}, &stack{})
+}
+
+// BreadthFirstContext is breadth first search like BreadthFirstSearch of hduplooy/gosearch that can be stopped
+func BreadthFirstContext(ctx context.Context, start src.SearchF, limits Limits) (*Result, error) {
+	return run(ctx, start, limits, &Result{}, &queue{})
+}
+
+// BestCostContext is best first search on the lowest Cost like BestCostSearch of hduplooy/gosearch that can be
+// stopped
+func BestCostContext(ctx context.Context, start src.SearchF, limits Limits) (*Result, error) {
+	return run(ctx, start, limits, &Result{}, &priority{prio: func(st src.SearchF) float64 { return st.Cost() }})
+}
+
+// BestCostAwayContext is A* (the lowest Cost plus Away first) like BestCostAwaySearch of hduplooy/gosearch that
+// can be stopped
+func BestCostAwayContext(ctx context.Context, start src.SearchF, limits Limits) (*Result, error) {
+	return run(ctx, start, limits, &Result{}, &priority{prio: func(st src.SearchF) float64 { return st.Cost() + st.Away() }})
+}
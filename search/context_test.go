@@ -0,0 +1,170 @@
+// context_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks that every search stops for each Reason with an *Error and returns the statistics of how far it got
+package search_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// tree is a state of a tree without a goal, the path is the branches taken from the root
+// Every state has 2 descendants, or 1 with chain, with depth 0 the tree goes on forever, else the states at that
+// depth have no descendants
+// If cancel is not nil it is called when calls reaches after
+type tree struct {
+	path   string
+	depth  int
+	chain  bool
+	calls  *int64
+	after  int64
+	cancel func()
+}
+
+func (tr *tree) Descendants() []src.SearchF {
+	if tr.cancel != nil && atomic.AddInt64(tr.calls, 1) == tr.after {
+		tr.cancel()
+	}
+	if tr.depth > 0 && len(tr.path) >= tr.depth {
+		return nil
+	}
+	steps := []string{"0", "1"}
+	if tr.chain {
+		steps = steps[:1]
+	}
+	tmp := make([]src.SearchF, len(steps))
+	for i, step := range steps {
+		child := *tr
+		child.path += step
+		tmp[i] = &child
+	}
+	return tmp
+}
+func (tr *tree) Done() bool     { return false }
+func (tr *tree) Cost() float64  { return float64(len(tr.path)) }
+func (tr *tree) Away() float64  { return 0 }
+func (tr *tree) Key() string    { return "t" + tr.path }
+func (tr *tree) String() string { return tr.path }
+
+// The searches that can be stopped, all with the same signature
+var searches = []struct {
+	name string
+	find func(ctx context.Context, start src.SearchF, limits search.Limits) (*search.Result, error)
+}{
+	{"DepthFirst", search.DepthFirstContext},
+	{"BreadthFirst", search.BreadthFirstContext},
+	{"BestCost", search.BestCostContext},
+	{"BestCostAway", search.BestCostAwayContext},
+	{"IterativeDeepening", search.IterativeDeepeningContext},
+	{"IDAStar", search.IDAStarContext},
+	{"Greedy", search.GreedyContext},
+	{"Weighted", func(ctx context.Context, start src.SearchF, limits search.Limits) (*search.Result, error) {
+		return search.WeightedContext(ctx, start, 2, limits)
+	}},
+	{"Beam", func(ctx context.Context, start src.SearchF, limits search.Limits) (*search.Result, error) {
+		return search.BeamContext(ctx, start, 1<<20, limits)
+	}},
+	{"Parallel", func(ctx context.Context, start src.SearchF, limits search.Limits) (*search.Result, error) {
+		return search.ParallelContext(ctx, start, 4, limits)
+	}},
+}
+
+// stopped checks that the search stopped for reason without a goal and that the error has the statistics of the
+// result, it returns the result
+func stopped(t *testing.T, name string, res *search.Result, err error, reason search.Reason) *search.Result {
+	t.Helper()
+	var serr *search.Error
+	if !errors.As(err, &serr) {
+		t.Fatalf("%s: got error %v, expected an *Error", name, err)
+	}
+	if serr.Reason != reason {
+		t.Errorf("%s: stopped with %v, expected %v", name, serr.Reason, reason)
+	}
+	if res == nil {
+		t.Fatalf("%s: no result returned with the error", name)
+	}
+	if res.Goal != nil || res.Path != nil {
+		t.Errorf("%s: found goal %v with path %v", name, res.Goal, res.Path)
+	}
+	if serr.Stats != res.Stats {
+		t.Errorf("%s: the error has statistics %+v and the result %+v", name, serr.Stats, res.Stats)
+	}
+	if res.Expanded == 0 || res.Generated == 0 || res.Memory == 0 {
+		t.Errorf("%s: statistics of how far it got are missing: %+v", name, res.Stats)
+	}
+	return res
+}
+
+func TestNoSolution(t *testing.T) {
+	for _, s := range searches {
+		res, err := s.find(context.Background(), &tree{depth: 9}, search.Limits{})
+		res = stopped(t, s.name, res, err, search.NoSolution)
+		if errors.Unwrap(err) != nil {
+			t.Errorf("%s: error %v wraps %v", s.name, err, errors.Unwrap(err))
+		}
+		// All 1023 states of the tree are expanded, the iterative deepening ones more than once
+		if res.Expanded < 1023 {
+			t.Errorf("%s: expanded %d states of the 1023", s.name, res.Expanded)
+		}
+	}
+}
+
+func TestCancelled(t *testing.T) {
+	for _, s := range searches {
+		ctx, cancel := context.WithCancel(context.Background())
+		start := &tree{calls: new(int64), after: 1000, cancel: cancel}
+		res, err := s.find(ctx, start, search.Limits{})
+		cancel()
+		res = stopped(t, s.name, res, err, search.Cancelled)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: error %v does not wrap context.Canceled", s.name, err)
+		}
+		if res.Expanded < 1000 {
+			t.Errorf("%s: expanded %d states but was only cancelled after 1000", s.name, res.Expanded)
+		}
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	for _, s := range searches {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		res, err := s.find(ctx, &tree{}, search.Limits{})
+		cancel()
+		stopped(t, s.name, res, err, search.Cancelled)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s: error %v does not wrap context.DeadlineExceeded", s.name, err)
+		}
+	}
+}
+
+func TestMaxExpansions(t *testing.T) {
+	for _, s := range searches {
+		res, err := s.find(context.Background(), &tree{}, search.Limits{MaxExpansions: 500})
+		res = stopped(t, s.name, res, err, search.ExpansionsExceeded)
+		if errors.Unwrap(err) != nil {
+			t.Errorf("%s: error %v wraps %v", s.name, err, errors.Unwrap(err))
+		}
+		if res.Expanded > 500 {
+			t.Errorf("%s: expanded %d states with a budget of 500", s.name, res.Expanded)
+		}
+	}
+}
+
+func TestMaxMemory(t *testing.T) {
+	const budget = 100 * search.StateSize
+	for _, s := range searches {
+		// The iterative deepening searches only keep the path, so it must get long
+		res, err := s.find(context.Background(), &tree{chain: true}, search.Limits{MaxMemory: budget})
+		res = stopped(t, s.name, res, err, search.MemoryExceeded)
+		if res.Memory <= budget {
+			t.Errorf("%s: stopped using %d bytes with a budget of %d", s.name, res.Memory, budget)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// deepening.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Iterative deepening searches only keep the path from the start to the state being expanded in memory,
+// instead of the whole frontier and the closed states, at the price of expanding states again in every iteration
+// IterativeDeepening does depth first searches of 1, 2, ... steps deep and so finds the goal with the fewest steps
+// like BreadthFirstSearch, IDAStar bounds the depth first searches by Cost+Away instead and finds the cheapest
+// goal like BestCostAwaySearch (if Away never overestimates)
+// There is no closed set, a descendant is only dropped (and counted as a duplicate) if its key is on the path to it
+package search
+
+import (
+	"context"
+	"math"
+	"time"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// deepening is an iterative deepening search
+// value gives the value of a node that is bounded, bound is the bound of the current iteration and next the
+// lowest value above it seen in this iteration (the bound of the next one)
+// onPath has the keys of the states on the path to the node being expanded
+type deepening struct {
+	ctx    context.Context
+	limits Limits
+	res    *Result
+	value  func(*node) float64
+	bound  float64
+	next   float64
+	onPath map[string]struct{}
+	reason Reason
+	err    error
+}
+
+// dfs expands the node and searches below it within the bound, it returns the goal or nil
+// If the search must stop reason is set
+func (d *deepening) dfs(n *node) *node {
+	stats := &d.res.Stats
+	if stats.Expanded%CheckEvery == 0 {
+		select {
+		case <-d.ctx.Done():
+			d.reason, d.err = Cancelled, d.ctx.Err()
+			return nil
+		default:
+		}
+	}
+	if d.limits.MaxExpansions > 0 && stats.Expanded >= d.limits.MaxExpansions {
+		d.reason = ExpansionsExceeded
+		return nil
+	}
+	stats.Expanded++
+	obs := d.limits.Observer
+	if obs != nil {
+		obs.OnExpand(n.event(stats.Expanded))
+	}
+	if n.state.Done() {
+		if obs != nil {
+			obs.OnGoal(n.event(stats.Expanded))
+		}
+		return n
+	}
+	for _, desc := range n.state.Descendants() {
+		stats.Generated++
+		child := &node{state: desc, key: desc.Key(), parent: n, depth: n.depth + 1}
+		if obs != nil {
+			obs.OnGenerate(child.event(0))
+		}
+		if _, ok := d.onPath[child.key]; ok {
+			stats.Duplicates++
+			if obs != nil {
+				obs.OnDuplicate(child.event(0))
+			}
+			continue
+		}
+		if val := d.value(child); val > d.bound {
+			if val < d.next {
+				d.next = val
+			}
+			continue
+		}
+		d.onPath[child.key] = struct{}{}
+		stats.Memory += StateSize + int64(len(child.key))
+		if len(d.onPath) > stats.PeakFrontier {
+			stats.PeakFrontier = len(d.onPath)
+		}
+		if d.limits.MaxMemory > 0 && stats.Memory > d.limits.MaxMemory {
+			d.reason = MemoryExceeded
+			return nil
+		}
+		goal := d.dfs(child)
+		if goal != nil || d.reason != NoSolution {
+			return goal
+		}
+		delete(d.onPath, child.key)
+		stats.Memory -= StateSize + int64(len(child.key))
+	}
+	return nil
+}
+
+// deepen does the iterations until the goal is found, there is nothing above the bound left or it must stop
+// PeakFrontier of the statistics is the longest path kept and Iterations the number of depth first searches
+func deepen(ctx context.Context, start src.SearchF, limits Limits, value func(*node) float64) (*Result, error) {
+	tm := time.Now()
+	d := &deepening{ctx: ctx, limits: limits, res: &Result{}, value: value}
+	n := &node{state: start, key: start.Key()}
+	d.bound = value(n)
+	for {
+		d.res.Iterations++
+		d.next = math.Inf(1)
+		d.onPath = map[string]struct{}{n.key: {}}
+		d.res.Memory = StateSize + int64(len(n.key))
+		if d.res.PeakFrontier == 0 {
+			d.res.PeakFrontier = 1
+		}
+		goal := d.dfs(n)
+		if goal != nil || d.reason != NoSolution || math.IsInf(d.next, 1) {
+			d.res.Frontier = len(d.onPath)
+			d.res.Time = time.Since(tm)
+			if goal == nil {
+				return d.res, &Error{d.reason, d.err, d.res.Stats}
+			}
+			d.res.Goal, d.res.Cost = goal.state, goal.state.Cost()
+			for p := goal; p != nil; p = p.parent {
+				d.res.Path = append(d.res.Path, p.state)
+			}
+			for i, j := 0, len(d.res.Path)-1; i < j; i, j = i+1, j-1 {
+				d.res.Path[i], d.res.Path[j] = d.res.Path[j], d.res.Path[i]
+			}
+			return d.res, nil
+		}
+		d.bound = d.next
+	}
+}
+
+// IterativeDeepeningContext is iterative deepening depth first search that can be stopped
+// The goal found has the fewest steps from the start
+// If there is no goal the error is an *Error saying why, the result is returned either way
+func IterativeDeepeningContext(ctx context.Context, start src.SearchF, limits Limits) (*Result, error) {
+	return deepen(ctx, start, limits, func(n *node) float64 { return float64(n.depth) })
+}
+
+// IDAStarContext is IDA* that can be stopped, the bound of every iteration is on Cost+Away
+// The goal found is the cheapest if Away never overestimates the cost still to go
+func IDAStarContext(ctx context.Context, start src.SearchF, limits Limits) (*Result, error) {
+	return deepen(ctx, start, limits, func(n *node) float64 { return n.state.Cost() + n.state.Away() })
+}
+
+// IterativeDeepening does iterative deepening depth first search
+func IterativeDeepening(start src.SearchF) *Result {
+	res, _ := IterativeDeepeningContext(context.Background(), start, Limits{})
+	return res
+}
+
+// IDAStar does IDA*
+func IDAStar(start src.SearchF) *Result {
+	res, _ := IDAStarContext(context.Background(), start, Limits{})
+	return res
+}
@@ -0,0 +1,75 @@
+// deepening_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks that IDA* and iterative deepening find solutions as short as A* and breadth first search do
+package search_test
+
+import (
+	"context"
+	"testing"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/queens"
+	"github.com/hduplooy/gosearch-test/search"
+	"github.com/hduplooy/gosearch-test/tiles"
+)
+
+// mostBreadth is the longest 8-puzzle solution searched for without a heuristic
+const mostBreadth = 20
+
+func TestDeepeningTiles(t *testing.T) {
+	finds := []struct {
+		name string
+		find func(context.Context, src.SearchF, search.Limits) (*search.Result, error)
+		h    bool
+	}{
+		{"A*", search.BestCostAwayContext, true},
+		{"IDA*", search.IDAStarContext, true},
+		{"BFS", search.BreadthFirstContext, false},
+		{"IDDFS", search.IterativeDeepeningContext, false},
+	}
+	for _, inst := range tiles.Instances {
+		game, start, err := inst.Start(tiles.LinearConflict)
+		if err != nil {
+			t.Fatalf("%s: %v", inst.Tiles, err)
+		}
+		for _, val := range finds {
+			if !val.h && (game.Size > 3 || inst.Moves > mostBreadth) {
+				continue
+			}
+			res, err := val.find(context.Background(), start, search.Limits{})
+			if err != nil {
+				t.Errorf("%s with %s: %v", inst.Tiles, val.name, err)
+				continue
+			}
+			if res.Cost != float64(inst.Moves) || len(res.Path) != inst.Moves+1 {
+				t.Errorf("%s with %s: %v moves and a path of %d states, expected %d moves", inst.Tiles, val.name, res.Cost, len(res.Path), inst.Moves)
+			}
+		}
+	}
+}
+
+func TestDeepeningQueens(t *testing.T) {
+	finds := []struct {
+		name string
+		find func(context.Context, src.SearchF, search.Limits) (*search.Result, error)
+	}{
+		{"DFS", search.DepthFirstContext},
+		{"IDDFS", search.IterativeDeepeningContext},
+		{"IDA*", search.IDAStarContext},
+	}
+	for n := 1; n <= 10; n++ {
+		// Only 2 and 3 queens can not be placed
+		want := n != 2 && n != 3
+		for _, val := range finds {
+			res, err := val.find(context.Background(), queens.NewBoard(n), search.Limits{})
+			if found := err == nil; found != want {
+				t.Errorf("%d queens with %s: found %v, expected %v (%v)", n, val.name, found, want, err)
+				continue
+			}
+			if want && len(res.Path) != n+1 {
+				t.Errorf("%d queens with %s: path of %d boards, expected %d", n, val.name, len(res.Path), n+1)
+			}
+		}
+	}
+}
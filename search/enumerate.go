@@ -0,0 +1,38 @@
+// enumerate.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Package search contains search routines that complement those in hduplooy/gosearch
+package search
+
+import (
+	src "github.com/hduplooy/gosearch"
+)
+
+// Enumerate does an exhaustive depth first search from start calling found for every goal state reached
+// Unlike DepthFirstSearch of hduplooy/gosearch it does not stop at the first goal
+// No record is kept of states already visited, so it is meant for state spaces that are trees
+// (for example placing queens rank by rank) otherwise the same goal will be reported more than once
+// Goal states are not expanded any further
+// If found returns false the enumeration stops
+// The number of states expanded is returned
+func Enumerate(start src.SearchF, found func(src.SearchF) bool) int {
+	cnt := 0
+	stack := []src.SearchF{start}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		cnt++
+		if cur.Done() {
+			if !found(cur) {
+				break
+			}
+			continue
+		}
+		// Push the descendants in reverse so that they are visited in the order they were generated
+		desc := cur.Descendants()
+		for i := len(desc) - 1; i >= 0; i-- {
+			stack = append(stack, desc[i])
+		}
+	}
+	return cnt
+}
@@ -0,0 +1,95 @@
+// local.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Local search works on complete assignments and keeps changing them until no constraint is violated
+// It does not find every solution and cannot prove that there is none, but it can handle problems far too big for
+// the systematic searches (like a million queens)
+package search
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Move is a change to a LocalF state
+// What A and B mean is up to the state (set variable A to value B, or swap the values of variables A and B, etc.)
+type Move struct {
+	A, B int
+}
+
+// LocalF must be implemented by states used in local search
+// Conflicts returns the number of violated constraints, when it is 0 the state is a solution
+// Restart replaces the assignment with a new starting one
+// BestMove picks a random variable in conflict and returns the move for it that leaves the fewest conflicts
+// RandomMove picks a random variable in conflict and returns a random move for it
+// Both BestMove and RandomMove also return the change in the number of conflicts the move would make
+// Apply makes the move
+type LocalF interface {
+	Conflicts() int
+	Restart(rnd *rand.Rand)
+	BestMove(rnd *rand.Rand) (Move, int)
+	RandomMove(rnd *rand.Rand) (Move, int)
+	Apply(m Move)
+}
+
+// LocalStats keeps track of what the local search did
+// Steps is the number of moves considered over all the restarts
+// Moves is the number of moves actually made
+// Restarts is the number of times the search started over
+type LocalStats struct {
+	Steps    int
+	Moves    int
+	Restarts int
+}
+
+// MinConflicts repeatedly makes the best move for a random variable in conflict
+// If there is no solution after maxSteps the state is restarted, up to maxRestarts times
+// It returns whether a solution was found (state then holds it) and the statistics
+func MinConflicts(state LocalF, maxSteps, maxRestarts int, rnd *rand.Rand) (bool, LocalStats) {
+	var stats LocalStats
+	for {
+		for i := 0; i < maxSteps && state.Conflicts() > 0; i++ {
+			m, _ := state.BestMove(rnd)
+			state.Apply(m)
+			stats.Steps++
+			stats.Moves++
+		}
+		if state.Conflicts() == 0 {
+			return true, stats
+		}
+		if stats.Restarts == maxRestarts {
+			return false, stats
+		}
+		stats.Restarts++
+		state.Restart(rnd)
+	}
+}
+
+// SimulatedAnnealing tries random moves for variables in conflict
+// A move that does not increase the conflicts is always made, one that does is made with probability exp(-delta/T)
+// The temperature T starts at temp and is multiplied by cooling after every step
+// If there is no solution after maxSteps the state is restarted (and the temperature reset), up to maxRestarts times
+// It returns whether a solution was found (state then holds it) and the statistics
+func SimulatedAnnealing(state LocalF, temp, cooling float64, maxSteps, maxRestarts int, rnd *rand.Rand) (bool, LocalStats) {
+	var stats LocalStats
+	for {
+		t := temp
+		for i := 0; i < maxSteps && state.Conflicts() > 0; i++ {
+			m, delta := state.RandomMove(rnd)
+			stats.Steps++
+			if delta <= 0 || rnd.Float64() < math.Exp(-float64(delta)/t) {
+				state.Apply(m)
+				stats.Moves++
+			}
+			t *= cooling
+		}
+		if state.Conflicts() == 0 {
+			return true, stats
+		}
+		if stats.Restarts == maxRestarts {
+			return false, stats
+		}
+		stats.Restarts++
+		state.Restart(rnd)
+	}
+}
@@ -0,0 +1,80 @@
+// observer.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Hooks to see what a search does, for example to debug a heuristic
+// An Observer given in the Limits of a search is told about every state expanded, generated, dropped as a
+// duplicate and the goal found
+package search
+
+import (
+	src "github.com/hduplooy/gosearch"
+)
+
+// Event is a step of the search
+// State is the state concerned and Key its key, Parent is the key of the state it was generated from ("" for the start)
+// Depth is the number of steps from the start
+// Seq is the number of the expansion (counted from 1) for OnExpand and OnGoal, it is 0 for the others
+type Event struct {
+	State  src.SearchF
+	Key    string
+	Parent string
+	Depth  int
+	Seq    int
+}
+
+// Observer is told about the steps of a search
+// OnExpand is called when a state is taken from the frontier to be expanded (also for the goal, before OnGoal)
+// OnGenerate is called for every descendant of a state expanded
+// OnDuplicate is called when a state is dropped because a state with the same key was already expanded
+// OnGoal is called when the goal is found
+type Observer interface {
+	OnExpand(ev Event)
+	OnGenerate(ev Event)
+	OnDuplicate(ev Event)
+	OnGoal(ev Event)
+}
+
+// Hooks is an Observer made of functions, those that are nil are not called
+type Hooks struct {
+	Expand    func(Event)
+	Generate  func(Event)
+	Duplicate func(Event)
+	Goal      func(Event)
+}
+
+// OnExpand calls Expand if it is set
+func (h Hooks) OnExpand(ev Event) {
+	if h.Expand != nil {
+		h.Expand(ev)
+	}
+}
+
+// OnGenerate calls Generate if it is set
+func (h Hooks) OnGenerate(ev Event) {
+	if h.Generate != nil {
+		h.Generate(ev)
+	}
+}
+
+// OnDuplicate calls Duplicate if it is set
+func (h Hooks) OnDuplicate(ev Event) {
+	if h.Duplicate != nil {
+		h.Duplicate(ev)
+	}
+}
+
+// OnGoal calls Goal if it is set
+func (h Hooks) OnGoal(ev Event) {
+	if h.Goal != nil {
+		h.Goal(ev)
+	}
+}
+
+// event returns the event for the node
+func (n *node) event(seq int) Event {
+	ev := Event{State: n.state, Key: n.key, Depth: n.depth, Seq: seq}
+	if n.parent != nil {
+		ev.Parent = n.parent.key
+	}
+	return ev
+}
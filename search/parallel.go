@@ -0,0 +1,299 @@
+// parallel.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Parallel A* that spreads the states over goroutines by the hash of their key (hash distributed A*, HDA*)
+// Every worker has its own frontier and closed states for the keys it owns and the descendants it generates for keys
+// of other workers are sent to them, so no state is shared and the only locks are on the inboxes
+// The goals are found in a different order than by A* so a worker keeps on searching until no state left anywhere
+// can lead to a cheaper goal, which makes the cost found the cheapest like that of BestCostAwaySearch
+package search
+
+import (
+	"container/heap"
+	"context"
+	"hash/maphash"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// worker is a goroutine of parallel A* with the states of the keys it owns
+// inbox has the states sent to it by the other workers (guarded by mu) and wake is signalled when one is sent
+// best has the lowest Cost every key was reached with and closed the keys expanded
+type worker struct {
+	mu     sync.Mutex
+	inbox  []*node
+	wake   chan struct{}
+	open   nodeHeap
+	seq    int
+	best   map[string]float64
+	closed map[string]struct{}
+	stats  Stats
+}
+
+// hda is what the workers of parallel A* share
+// active is the number of workers not waiting for states and inflight the number of states sent but not yet taken
+// from an inbox, the search is done when both are 0 (active is guarded by mu)
+// cheapest is the cost of goal (as float bits) so that the workers can read it without a lock
+// quit is closed when the search stops, why is in reason and err
+// yield is true if there are more workers than CPUs running Go code, they then yield after every expansion so
+// that they take turns (else one of them runs on for a long time expanding states the others would have dropped)
+type hda struct {
+	ctx      context.Context
+	limits   Limits
+	workers  []*worker
+	seed     maphash.Seed
+	mu       sync.Mutex
+	active   int
+	inflight atomic.Int64
+	cheapest atomic.Uint64
+	goal     *node
+	expanded atomic.Int64
+	memory   atomic.Int64
+	obsMu    sync.Mutex
+	yield    bool
+	quit     chan struct{}
+	once     sync.Once
+	reason   Reason
+	err      error
+}
+
+// owner returns the worker owning the key
+func (h *hda) owner(key string) *worker {
+	return h.workers[maphash.String(h.seed, key)%uint64(len(h.workers))]
+}
+
+// stop stops all the workers, only the first reason given counts
+func (h *hda) stop(reason Reason, err error) {
+	h.once.Do(func() {
+		h.reason, h.err = reason, err
+		close(h.quit)
+	})
+}
+
+// observe tells the observer about an event, the workers take turns
+func (h *hda) observe(f func(Observer)) {
+	if h.limits.Observer != nil {
+		h.obsMu.Lock()
+		f(h.limits.Observer)
+		h.obsMu.Unlock()
+	}
+}
+
+// push puts the state on the frontier of the worker unless its key was already reached as cheaply
+func (h *hda) push(w *worker, n *node) {
+	g := n.state.Cost()
+	if old, ok := w.best[n.key]; ok && old <= g {
+		w.stats.Duplicates++
+		h.observe(func(obs Observer) { obs.OnDuplicate(n.event(0)) })
+		return
+	}
+	w.best[n.key] = g
+	w.seq++
+	n.seq = w.seq
+	n.prio = g + n.state.Away()
+	heap.Push(&w.open, n)
+	h.memory.Add(StateSize + int64(len(n.key)))
+	if len(w.open) > w.stats.PeakFrontier {
+		w.stats.PeakFrontier = len(w.open)
+	}
+}
+
+// send gives the state to the worker owning its key
+func (h *hda) send(w *worker, n *node) {
+	to := h.owner(n.key)
+	if to == w {
+		h.push(w, n)
+		return
+	}
+	w.stats.Messages++
+	h.inflight.Add(1)
+	to.mu.Lock()
+	to.inbox = append(to.inbox, n)
+	to.mu.Unlock()
+	select {
+	case to.wake <- struct{}{}:
+	default:
+	}
+}
+
+// receive puts the states in the inbox of the worker on its frontier
+func (h *hda) receive(w *worker) {
+	w.mu.Lock()
+	msgs := w.inbox
+	w.inbox = nil
+	w.mu.Unlock()
+	for _, n := range msgs {
+		h.push(w, n)
+	}
+	h.inflight.Add(-int64(len(msgs)))
+}
+
+// expand expands the state with the lowest Cost plus Away of the worker, it returns false if there is none that can
+// lead to a goal cheaper than the one found (or the search was stopped)
+func (h *hda) expand(w *worker) bool {
+	for len(w.open) > 0 {
+		if w.open[0].prio >= math.Float64frombits(h.cheapest.Load()) {
+			return false
+		}
+		cur := heap.Pop(&w.open).(*node)
+		if cur.state.Cost() > w.best[cur.key] {
+			// Reached more cheaply after it was put on the frontier
+			h.memory.Add(-(StateSize + int64(len(cur.key))))
+			w.stats.Duplicates++
+			h.observe(func(obs Observer) { obs.OnDuplicate(cur.event(0)) })
+			continue
+		}
+		seq := int(h.expanded.Add(1))
+		if h.limits.MaxExpansions > 0 && seq > h.limits.MaxExpansions {
+			h.stop(ExpansionsExceeded, nil)
+			return false
+		}
+		w.stats.Expanded++
+		w.closed[cur.key] = struct{}{}
+		h.observe(func(obs Observer) { obs.OnExpand(cur.event(seq)) })
+		if cur.state.Done() {
+			h.observe(func(obs Observer) { obs.OnGoal(cur.event(seq)) })
+			h.mu.Lock()
+			if cost := cur.state.Cost(); h.goal == nil || cost < h.goal.state.Cost() {
+				h.goal = cur
+				h.cheapest.Store(math.Float64bits(cost))
+			}
+			h.mu.Unlock()
+			return true
+		}
+		for _, desc := range cur.state.Descendants() {
+			w.stats.Generated++
+			n := &node{state: desc, key: desc.Key(), parent: cur, depth: cur.depth + 1}
+			h.observe(func(obs Observer) { obs.OnGenerate(n.event(0)) })
+			h.send(w, n)
+		}
+		if h.limits.MaxMemory > 0 && h.memory.Load() > h.limits.MaxMemory {
+			h.stop(MemoryExceeded, nil)
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// wait is called when the worker has nothing to expand, it waits for states to be sent to it and returns false when
+// the search is done or stopped
+// The last worker to wait when no states are on the way to an inbox ends the search
+func (h *hda) wait(w *worker) bool {
+	h.mu.Lock()
+	w.mu.Lock()
+	pending := len(w.inbox) > 0
+	w.mu.Unlock()
+	if pending {
+		h.mu.Unlock()
+		return true
+	}
+	h.active--
+	if h.active == 0 && h.inflight.Load() == 0 {
+		h.mu.Unlock()
+		h.stop(NoSolution, nil)
+		return false
+	}
+	h.mu.Unlock()
+	select {
+	case <-w.wake:
+	case <-h.quit:
+		return false
+	case <-h.ctx.Done():
+		h.stop(Cancelled, h.ctx.Err())
+		return false
+	}
+	h.mu.Lock()
+	h.active++
+	h.mu.Unlock()
+	return true
+}
+
+// work is the loop of a worker
+func (h *hda) work(w *worker) {
+	for i := 0; ; i++ {
+		select {
+		case <-h.quit:
+			return
+		default:
+		}
+		if i%CheckEvery == 0 && h.ctx.Err() != nil {
+			h.stop(Cancelled, h.ctx.Err())
+			return
+		}
+		h.receive(w)
+		if !h.expand(w) && !h.wait(w) {
+			return
+		}
+		if h.yield {
+			runtime.Gosched()
+		}
+	}
+}
+
+// ParallelContext is parallel A* with the states spread over workers goroutines by the hash of their key
+// It finds a goal as cheap as that of BestCostAwayContext if Away never overestimates, but not always the same
+// goal or path when there is more than one as cheap. A state is expanded again if it is reached more cheaply after
+// it was expanded, as the workers do not expand the states in the order of A*
+// The statistics are those of all the workers added up (the peaks too), Messages is the number of states sent
+// from one worker to another
+// The Observer is told about the events of all the workers, one at a time
+func ParallelContext(ctx context.Context, start src.SearchF, workers int, limits Limits) (*Result, error) {
+	tm := time.Now()
+	if workers < 1 {
+		workers = 1
+	}
+	h := &hda{ctx: ctx, limits: limits, seed: maphash.MakeSeed(), active: workers, quit: make(chan struct{})}
+	h.cheapest.Store(math.Float64bits(math.Inf(1)))
+	h.yield = workers > runtime.GOMAXPROCS(0) || workers > runtime.NumCPU()
+	for i := 0; i < workers; i++ {
+		h.workers = append(h.workers, &worker{wake: make(chan struct{}, 1), best: make(map[string]float64), closed: make(map[string]struct{})})
+	}
+	key := start.Key()
+	first := h.owner(key)
+	h.push(first, &node{state: start, key: key})
+	var wg sync.WaitGroup
+	for _, w := range h.workers {
+		wg.Add(1)
+		go func(w *worker) {
+			defer wg.Done()
+			h.work(w)
+		}(w)
+	}
+	wg.Wait()
+
+	res := &Result{Time: time.Since(tm)}
+	for _, w := range h.workers {
+		res.Expanded += w.stats.Expanded
+		res.Generated += w.stats.Generated
+		res.Duplicates += w.stats.Duplicates
+		res.Messages += w.stats.Messages
+		res.PeakFrontier += w.stats.PeakFrontier
+		res.Frontier += len(w.open) + len(w.inbox)
+		res.Closed += len(w.closed)
+	}
+	res.PeakClosed = res.Closed
+	res.Memory = h.memory.Load()
+	if h.reason != NoSolution || h.goal == nil {
+		return res, &Error{h.reason, h.err, res.Stats}
+	}
+	res.Goal, res.Cost = h.goal.state, h.goal.state.Cost()
+	for p := h.goal; p != nil; p = p.parent {
+		res.Path = append(res.Path, p.state)
+	}
+	for i, j := 0, len(res.Path)-1; i < j; i, j = i+1, j-1 {
+		res.Path[i], res.Path[j] = res.Path[j], res.Path[i]
+	}
+	return res, nil
+}
+
+// Parallel does parallel A* with the number of workers given
+func Parallel(start src.SearchF, workers int) *Result {
+	res, _ := ParallelContext(context.Background(), start, workers, Limits{})
+	return res
+}
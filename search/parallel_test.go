@@ -0,0 +1,107 @@
+// parallel_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks that parallel A* finds goals as cheap as those of A* with any number of workers and stops on its limits
+// Run it with go test -race ./search to check the workers for data races as well
+package search_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/roads"
+	"github.com/hduplooy/gosearch-test/search"
+	"github.com/hduplooy/gosearch-test/tiles"
+)
+
+// workerCounts are the numbers of workers every problem is searched with
+var workerCounts = []int{1, 2, 4, 8}
+
+// puzzle returns the start state of the sliding tile puzzle solved with linear conflicts
+func puzzle(t *testing.T, inst tiles.Instance) src.SearchF {
+	t.Helper()
+	_, start, err := inst.Start(tiles.LinearConflict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return start
+}
+
+// drive returns the start state of a drive across a grid network of rows by rows cities
+func drive(t *testing.T, rows int) src.SearchF {
+	t.Helper()
+	net := roads.Grid(rows, rows, 1)
+	start, err := net.StartDrive("R0C0", fmt.Sprintf("R%dC%d", rows-1, rows-1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return start
+}
+
+func TestParallelCost(t *testing.T) {
+	type problem struct {
+		name  string
+		start src.SearchF
+	}
+	probs := []problem{{"roads 30x30", drive(t, 30)}}
+	// The puzzles that give the workers something to do but are quick enough for the race detector
+	for _, inst := range tiles.Instances {
+		if inst.Moves >= 24 && inst.Moves <= 31 {
+			probs = append(probs, problem{inst.Tiles, puzzle(t, inst)})
+		}
+	}
+	for _, prob := range probs {
+		base, _ := search.BestCostAwayContext(context.Background(), prob.start, search.Limits{})
+		if base.Goal == nil {
+			t.Fatalf("%s: A* found no goal", prob.name)
+		}
+		for _, n := range workerCounts {
+			res, err := search.ParallelContext(context.Background(), prob.start, n, search.Limits{})
+			if err != nil {
+				t.Errorf("%s with %d workers: %v", prob.name, n, err)
+				continue
+			}
+			if math.Abs(res.Cost-base.Cost) > 1e-6 {
+				t.Errorf("%s with %d workers: cost %g, A* found %g", prob.name, n, res.Cost, base.Cost)
+			}
+			if res.Path[0] != prob.start || res.Path[len(res.Path)-1] != res.Goal || !res.Goal.Done() {
+				t.Errorf("%s with %d workers: the path does not run from the start to the goal", prob.name, n)
+			}
+		}
+	}
+}
+
+func TestParallelMaxExpansions(t *testing.T) {
+	start := puzzle(t, tiles.Instance{Tiles: "8 6 7 2 5 4 3 0 1"})
+	for _, n := range workerCounts {
+		res, err := search.ParallelContext(context.Background(), start, n, search.Limits{MaxExpansions: 100})
+		var serr *search.Error
+		if !errors.As(err, &serr) || serr.Reason != search.ExpansionsExceeded {
+			t.Errorf("%d workers: got error %v, expected the expansion budget to be exceeded", n, err)
+			continue
+		}
+		if res.Goal != nil || res.Expanded > 100 {
+			t.Errorf("%d workers: expanded %d states and found goal %v", n, res.Expanded, res.Goal)
+		}
+	}
+}
+
+func TestParallelCancel(t *testing.T) {
+	start := puzzle(t, tiles.Instance{Tiles: "8 6 7 2 5 4 3 0 1"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, n := range workerCounts {
+		_, err := search.ParallelContext(ctx, start, n, search.Limits{})
+		var serr *search.Error
+		if !errors.As(err, &serr) || serr.Reason != search.Cancelled {
+			t.Errorf("%d workers: got error %v, expected the search to be cancelled", n, err)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%d workers: error %v does not wrap context.Canceled", n, err)
+		}
+	}
+}
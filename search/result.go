@@ -0,0 +1,143 @@
+// result.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// The searches of hduplooy/gosearch only return the number of steps, the goal and the history
+// DepthFirst, BreadthFirst, BestCost and BestCostAway call them and return a Result with the path and statistics
+// of the search as well, so that the examples all report the same things and can be compared
+// The statistics are counted by wrapping every state in counted, from the descendants hduplooy/gosearch asks for
+// hduplooy/gosearch does not drop a state it has seen before, it searches the same state again every time it is
+// reached, so on a state space where states can be reached in more than one way the searches of context.go should
+// be used instead
+package search
+
+import (
+	"fmt"
+	"time"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Result is what a search found
+// Goal is the goal state (nil if none was found), Path the states from the start to the goal and Cost the
+// cost of the goal. Time is how long the search took
+// Bound is how many times the cheapest cost the cost found can be at most for the searches that do not
+// find the cheapest but have such a bound (0 if there is none)
+type Result struct {
+	Goal  src.SearchF
+	Path  []src.SearchF
+	Cost  float64
+	Bound float64
+	Stats
+	Time time.Duration
+}
+
+// History returns the path the way hduplooy/gosearch returns it: from the parent of the goal back to the start
+func (res *Result) History() []src.SearchF {
+	if len(res.Path) == 0 {
+		return nil
+	}
+	tmp := make([]src.SearchF, len(res.Path)-1)
+	for i := range tmp {
+		tmp[i] = res.Path[len(res.Path)-2-i]
+	}
+	return tmp
+}
+
+// String is a summary of the statistics
+func (res *Result) String() string {
+	return fmt.Sprintf("Done in %d steps (%d generated, %d duplicates, peak frontier %d, peak closed %d) in %v",
+		res.Expanded, res.Generated, res.Duplicates, res.PeakFrontier, res.PeakClosed, res.Time.Round(time.Microsecond))
+}
+
+// counter collects the statistics of a search of hduplooy/gosearch from the descendants it asks for
+// seen has the keys of all the states generated, a descendant with a key already seen is counted as a duplicate
+// (it is still searched) and open is the number of states on the frontier, those generated less those expanded
+type counter struct {
+	stats *Stats
+	seen  map[string]struct{}
+	open  int
+}
+
+// counted is a state of a search of hduplooy/gosearch wrapped so that its descendants are counted
+type counted struct {
+	src.SearchF
+	cnt *counter
+}
+
+// Descendants returns the descendants of the state wrapped as well and counts them
+func (c counted) Descendants() []src.SearchF {
+	cnt, stats := c.cnt, c.cnt.stats
+	cnt.open--
+	stats.PeakClosed++
+	descs := c.SearchF.Descendants()
+	tmp := make([]src.SearchF, len(descs))
+	for i, desc := range descs {
+		stats.Generated++
+		key := desc.Key()
+		if _, ok := cnt.seen[key]; ok {
+			stats.Duplicates++
+		} else {
+			cnt.seen[key] = struct{}{}
+		}
+		cnt.open++
+		stats.Memory += StateSize + int64(len(key))
+		tmp[i] = counted{desc, cnt}
+	}
+	if cnt.open > stats.PeakFrontier {
+		stats.PeakFrontier = cnt.open
+	}
+	return tmp
+}
+
+// unwrap returns the state inside a counted state
+func unwrap(st src.SearchF) src.SearchF {
+	if c, ok := st.(counted); ok {
+		return c.SearchF
+	}
+	return st
+}
+
+// wrap does the search find of hduplooy/gosearch from start keeping the history and returns its Result
+func wrap(find func(src.SearchF, bool) (int, src.SearchF, []src.SearchF), start src.SearchF) *Result {
+	tm := time.Now()
+	res := &Result{}
+	key := start.Key()
+	cnt := &counter{stats: &res.Stats, seen: map[string]struct{}{key: {}}, open: 1}
+	res.Memory, res.PeakFrontier = StateSize+int64(len(key)), 1
+	steps, ans, hist := find(counted{start, cnt}, true)
+	res.Expanded, res.Closed, res.PeakClosed = steps, steps, steps
+	if cnt.open > 0 {
+		res.Frontier = cnt.open
+	}
+	res.Time = time.Since(tm)
+	if ans == nil {
+		return res
+	}
+	res.Goal = unwrap(ans)
+	res.Cost = res.Goal.Cost()
+	for i := len(hist) - 1; i >= 0; i-- {
+		res.Path = append(res.Path, unwrap(hist[i]))
+	}
+	res.Path = append(res.Path, res.Goal)
+	return res
+}
+
+// DepthFirst does DepthFirstSearch of hduplooy/gosearch
+func DepthFirst(start src.SearchF) *Result {
+	return wrap(src.DepthFirstSearch, start)
+}
+
+// BreadthFirst does BreadthFirstSearch of hduplooy/gosearch
+func BreadthFirst(start src.SearchF) *Result {
+	return wrap(src.BreadthFirstSearch, start)
+}
+
+// BestCost does BestCostSearch of hduplooy/gosearch (the lowest Cost first)
+func BestCost(start src.SearchF) *Result {
+	return wrap(src.BestCostSearch, start)
+}
+
+// BestCostAway does BestCostAwaySearch of hduplooy/gosearch (the lowest Cost plus Away first, A*)
+func BestCostAway(start src.SearchF) *Result {
+	return wrap(src.BestCostAwaySearch, start)
+}
@@ -0,0 +1,213 @@
+// stealing.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// A parallel version of Enumerate where the subtrees are shared out among goroutines by work stealing
+// Every worker does a depth first search from its own deque of states, taking the newest state, and a worker that
+// runs out takes the oldest state of another worker (the one closest to the start, so the biggest subtree)
+package search
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Enumeration is what EnumerateParallel did
+// Goals is the number of goals found, Expanded the number of states expanded (the steps of Enumerate), Steals the
+// number of states taken from another worker and Time how long it took
+type Enumeration struct {
+	Goals    int
+	Expanded int
+	Steals   int
+	Time     time.Duration
+}
+
+// deque holds the states still to be expanded by a worker, the owner works on the end and thieves take from first
+type deque struct {
+	mu     sync.Mutex
+	states []src.SearchF
+	first  int
+}
+
+func (d *deque) push(st src.SearchF) {
+	d.mu.Lock()
+	d.states = append(d.states, st)
+	d.mu.Unlock()
+}
+
+// pop takes the newest state, it returns nil if there is none
+func (d *deque) pop() src.SearchF {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.states) == d.first {
+		d.states, d.first = d.states[:0], 0
+		return nil
+	}
+	st := d.states[len(d.states)-1]
+	d.states[len(d.states)-1] = nil
+	d.states = d.states[:len(d.states)-1]
+	return st
+}
+
+// steal takes the oldest state, it returns nil if there is none
+func (d *deque) steal() src.SearchF {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.states) == d.first {
+		return nil
+	}
+	st := d.states[d.first]
+	d.states[d.first] = nil
+	d.first++
+	return st
+}
+
+// enumerator is what the workers of EnumerateParallel share
+// idle is the number of workers without states, it is only changed together with stealing (guarded by stealMu) so
+// when it reaches the number of workers there are no states left anywhere
+type enumerator struct {
+	ctx     context.Context
+	deques  []*deque
+	found   func(src.SearchF) bool
+	foundMu sync.Mutex
+	stealMu sync.Mutex
+	idle    int
+	done    atomic.Bool
+	err     error
+}
+
+// take finds a state for a worker without states, first its own and then by stealing
+// It returns nil when there are no states left or the enumeration was stopped
+func (e *enumerator) take(id int, rnd *rand.Rand, steals *int) src.SearchF {
+	if st := e.deques[id].pop(); st != nil {
+		return st
+	}
+	e.stealMu.Lock()
+	e.idle++
+	e.stealMu.Unlock()
+	for tries := 0; ; tries++ {
+		if e.done.Load() {
+			return nil
+		}
+		if err := e.ctx.Err(); err != nil {
+			e.stop(err)
+			return nil
+		}
+		e.stealMu.Lock()
+		if e.idle == len(e.deques) {
+			e.stealMu.Unlock()
+			e.done.Store(true)
+			return nil
+		}
+		// Start at a random worker so the thieves do not all go for the same one
+		first := rnd.Intn(len(e.deques))
+		for i := range e.deques {
+			victim := (first + i) % len(e.deques)
+			if victim == id {
+				continue
+			}
+			if st := e.deques[victim].steal(); st != nil {
+				e.idle--
+				e.stealMu.Unlock()
+				*steals++
+				return st
+			}
+		}
+		e.stealMu.Unlock()
+		if tries%64 == 63 {
+			time.Sleep(50 * time.Microsecond)
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
+
+// work is the loop of a worker, it returns the goals it found, the states it expanded and the states it stole
+func (e *enumerator) work(id int) (int, int, int) {
+	goals, cnt, steals := 0, 0, 0
+	rnd := rand.New(rand.NewSource(int64(id) + 1))
+	own := e.deques[id]
+	for {
+		cur := e.take(id, rnd, &steals)
+		if cur == nil {
+			return goals, cnt, steals
+		}
+		cnt++
+		if cnt%CheckEvery == 0 {
+			if e.done.Load() {
+				return goals, cnt, steals
+			}
+			if err := e.ctx.Err(); err != nil {
+				e.stop(err)
+				return goals, cnt, steals
+			}
+		}
+		if cur.Done() {
+			goals++
+			if e.found != nil {
+				e.foundMu.Lock()
+				more := !e.done.Load() && e.found(cur)
+				e.foundMu.Unlock()
+				if !more {
+					e.stop(nil)
+					return goals, cnt, steals
+				}
+			}
+			continue
+		}
+		// Push the descendants in reverse so that they are visited in the order they were generated
+		desc := cur.Descendants()
+		for i := len(desc) - 1; i >= 0; i-- {
+			own.push(desc[i])
+		}
+	}
+}
+
+// stop ends the enumeration with the error (nil if found asked to stop)
+func (e *enumerator) stop(err error) {
+	e.stealMu.Lock()
+	if !e.done.Load() {
+		e.err = err
+		e.done.Store(true)
+	}
+	e.stealMu.Unlock()
+}
+
+// EnumerateParallel does what Enumerate does with workers goroutines sharing out the subtrees by work stealing
+// found is called for every goal reached by the workers one at a time (so it needs no locking of its own) but not
+// in the order of Enumerate, if it returns false the enumeration stops. If found is nil the goals are only counted
+// It stops when the context is cancelled and then returns the error of the context with how far it got
+func EnumerateParallel(ctx context.Context, start src.SearchF, workers int, found func(src.SearchF) bool) (*Enumeration, error) {
+	tm := time.Now()
+	if workers < 1 {
+		workers = 1
+	}
+	e := &enumerator{ctx: ctx, found: found}
+	for i := 0; i < workers; i++ {
+		e.deques = append(e.deques, &deque{})
+	}
+	e.deques[0].push(start)
+	res := &Enumeration{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			goals, cnt, steals := e.work(id)
+			mu.Lock()
+			res.Goals += goals
+			res.Expanded += cnt
+			res.Steals += steals
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+	res.Time = time.Since(tm)
+	return res, e.err
+}
@@ -0,0 +1,76 @@
+// stealing_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks that EnumerateParallel finds the same goals as Enumerate with any number of workers and that it stops
+// Run it with go test -race ./search to check the workers for data races as well
+package search_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/queens"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+func TestEnumerateParallelCounts(t *testing.T) {
+	for n := 1; n <= 10; n++ {
+		want := 0
+		steps := search.Enumerate(queens.NewBoard(n), func(src.SearchF) bool {
+			want++
+			return true
+		})
+		for workers := 1; workers <= 8; workers++ {
+			calls := 0
+			res, err := search.EnumerateParallel(context.Background(), queens.NewBoard(n), workers, func(src.SearchF) bool {
+				calls++
+				return true
+			})
+			if err != nil {
+				t.Errorf("N=%d with %d workers: %v", n, workers, err)
+				continue
+			}
+			if res.Goals != want || calls != want {
+				t.Errorf("N=%d with %d workers: %d goals and %d calls of found, Enumerate found %d", n, workers, res.Goals, calls, want)
+			}
+			if res.Expanded != steps {
+				t.Errorf("N=%d with %d workers: expanded %d states, Enumerate expanded %d", n, workers, res.Expanded, steps)
+			}
+		}
+	}
+}
+
+func TestEnumerateParallelStops(t *testing.T) {
+	for workers := 1; workers <= 8; workers++ {
+		calls := 0
+		res, err := search.EnumerateParallel(context.Background(), queens.NewBoard(10), workers, func(src.SearchF) bool {
+			calls++
+			return calls < 5
+		})
+		if err != nil {
+			t.Errorf("%d workers: %v", workers, err)
+		}
+		if calls != 5 {
+			t.Errorf("%d workers: found was called %d times after it returned false the 5th time", workers, calls)
+		}
+		if res.Goals >= 724 {
+			t.Errorf("%d workers: found all %d goals", workers, res.Goals)
+		}
+	}
+}
+
+func TestEnumerateParallelCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for workers := 1; workers <= 8; workers++ {
+		res, err := search.EnumerateParallel(ctx, queens.NewBoard(10), workers, nil)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%d workers: got error %v, expected %v", workers, err, context.Canceled)
+		}
+		if res.Goals >= 724 {
+			t.Errorf("%d workers: found all %d goals", workers, res.Goals)
+		}
+	}
+}
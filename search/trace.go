@@ -0,0 +1,115 @@
+// trace.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// A Recorder is an Observer that writes what the search did to JSON Lines, one TraceRecord per line
+// ReadTrace reads such a trace back, for example to summarize it
+package search
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// The events of a trace
+const (
+	TraceExpand    = "expand"
+	TraceGenerate  = "generate"
+	TraceDuplicate = "duplicate"
+	TraceGoal      = "goal"
+)
+
+// TraceRecord is a line of a trace
+// Event is one of TraceExpand, TraceGenerate, TraceDuplicate or TraceGoal and Seq the number of the expansion
+// Key and Parent are the keys of the state and the state it was generated from and Label describes the state
+// G is the cost so far (Cost of the state), H the estimate of the cost still to go (Away) and F their sum
+type TraceRecord struct {
+	Event  string  `json:"event"`
+	Seq    int     `json:"seq,omitempty"`
+	Key    string  `json:"key"`
+	Parent string  `json:"parent,omitempty"`
+	Label  string  `json:"label,omitempty"`
+	Depth  int     `json:"depth"`
+	G      float64 `json:"g"`
+	H      float64 `json:"h"`
+	F      float64 `json:"f"`
+}
+
+// Recorder writes the trace of a search
+// Label gives the label of a state, if it is nil the states are not labelled
+// Generated says if the states generated are recorded as well, they are left out by default because
+// they make the trace a lot bigger and most of them are expanded (and recorded) later anyway
+// Only the first error writing the trace is kept, it is returned by Flush
+type Recorder struct {
+	Label     func(src.SearchF) string
+	Generated bool
+	w         *bufio.Writer
+	enc       *json.Encoder
+	err       error
+}
+
+// NewRecorder returns a recorder writing to w, Flush must be called when the search is done
+func NewRecorder(w io.Writer) *Recorder {
+	buf := bufio.NewWriter(w)
+	return &Recorder{w: buf, enc: json.NewEncoder(buf)}
+}
+
+// record writes the event
+func (rec *Recorder) record(event string, ev Event) {
+	if rec.err != nil {
+		return
+	}
+	tr := TraceRecord{Event: event, Seq: ev.Seq, Key: ev.Key, Parent: ev.Parent, Depth: ev.Depth}
+	tr.G, tr.H = ev.State.Cost(), ev.State.Away()
+	tr.F = tr.G + tr.H
+	if rec.Label != nil {
+		tr.Label = rec.Label(ev.State)
+	}
+	rec.err = rec.enc.Encode(&tr)
+}
+
+// OnExpand records the state expanded
+func (rec *Recorder) OnExpand(ev Event) { rec.record(TraceExpand, ev) }
+
+// OnGenerate records the state generated if Generated is set
+func (rec *Recorder) OnGenerate(ev Event) {
+	if rec.Generated {
+		rec.record(TraceGenerate, ev)
+	}
+}
+
+// OnDuplicate records the duplicate dropped
+func (rec *Recorder) OnDuplicate(ev Event) { rec.record(TraceDuplicate, ev) }
+
+// OnGoal records the goal
+func (rec *Recorder) OnGoal(ev Event) { rec.record(TraceGoal, ev) }
+
+// Flush writes what is still buffered and returns the first error writing the trace
+func (rec *Recorder) Flush() error {
+	if rec.err != nil {
+		return rec.err
+	}
+	rec.err = rec.w.Flush()
+	return rec.err
+}
+
+// ReadTrace reads all the records of a trace
+func ReadTrace(r io.Reader) ([]TraceRecord, error) {
+	var tmp []TraceRecord
+	scan := bufio.NewScanner(r)
+	scan.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scan.Scan(); line++ {
+		if len(scan.Bytes()) == 0 {
+			continue
+		}
+		var tr TraceRecord
+		if err := json.Unmarshal(scan.Bytes(), &tr); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		tmp = append(tmp, tr)
+	}
+	return tmp, scan.Err()
+}
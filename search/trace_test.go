@@ -0,0 +1,124 @@
+// trace_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks the trace a Recorder writes of a small A* search when it is read back with ReadTrace
+package search_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// The paths of a small graph with their lengths and the estimate of every place to the goal G
+// From B the path back to A reaches A after it was expanded, so it is a duplicate
+var (
+	paths = map[string][]struct {
+		to   string
+		dist float64
+	}{
+		"S": {{"A", 1}, {"B", 4}},
+		"A": {{"B", 1}, {"G", 5}},
+		"B": {{"A", 1}, {"G", 1}},
+	}
+	away = map[string]float64{"S": 2, "A": 2, "B": 1, "G": 0}
+)
+
+// place is a state of the small graph, the place reached and the distance travelled
+type place struct {
+	name string
+	dist float64
+}
+
+func (pl place) Descendants() []src.SearchF {
+	var tmp []src.SearchF
+	for _, path := range paths[pl.name] {
+		tmp = append(tmp, place{path.to, pl.dist + path.dist})
+	}
+	return tmp
+}
+func (pl place) Done() bool     { return pl.name == "G" }
+func (pl place) Cost() float64  { return pl.dist }
+func (pl place) Away() float64  { return away[pl.name] }
+func (pl place) Key() string    { return pl.name }
+func (pl place) String() string { return pl.name }
+
+// trace searches the small graph with A* and returns the trace read back
+func trace(t *testing.T, generated bool) []search.TraceRecord {
+	t.Helper()
+	var buf bytes.Buffer
+	rec := search.NewRecorder(&buf)
+	rec.Generated = generated
+	rec.Label = func(st src.SearchF) string { return "at " + st.Key() }
+	res, err := search.BestCostAwayContext(context.Background(), place{"S", 0}, search.Limits{Observer: rec})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if res.Cost != 3 {
+		t.Errorf("cost %v, want 3", res.Cost)
+	}
+	if err := rec.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	trs, err := search.ReadTrace(&buf)
+	if err != nil {
+		t.Fatalf("read trace: %v", err)
+	}
+	return trs
+}
+
+func TestRecorder(t *testing.T) {
+	rec := func(event string, seq int, key, parent string, depth int, g float64) search.TraceRecord {
+		return search.TraceRecord{Event: event, Seq: seq, Key: key, Parent: parent, Label: "at " + key, Depth: depth,
+			G: g, H: away[key], F: g + away[key]}
+	}
+	want := []search.TraceRecord{
+		rec(search.TraceExpand, 1, "S", "", 0, 0),
+		rec(search.TraceGenerate, 0, "A", "S", 1, 1),
+		rec(search.TraceGenerate, 0, "B", "S", 1, 4),
+		rec(search.TraceExpand, 2, "A", "S", 1, 1),
+		rec(search.TraceGenerate, 0, "B", "A", 2, 2),
+		rec(search.TraceGenerate, 0, "G", "A", 2, 6),
+		rec(search.TraceExpand, 3, "B", "A", 2, 2),
+		rec(search.TraceGenerate, 0, "A", "B", 3, 3),
+		rec(search.TraceDuplicate, 0, "A", "B", 3, 3),
+		rec(search.TraceGenerate, 0, "G", "B", 3, 3),
+		rec(search.TraceExpand, 4, "G", "B", 3, 3),
+		rec(search.TraceGoal, 4, "G", "B", 3, 3),
+	}
+	for _, generated := range []bool{true, false} {
+		exp := want
+		if !generated {
+			exp = nil
+			for _, tr := range want {
+				if tr.Event != search.TraceGenerate {
+					exp = append(exp, tr)
+				}
+			}
+		}
+		got := trace(t, generated)
+		if len(got) != len(exp) {
+			t.Errorf("generated %v: %d records, want %d", generated, len(got), len(exp))
+			continue
+		}
+		for i := range exp {
+			if got[i] != exp[i] {
+				t.Errorf("generated %v: record %d is %+v, want %+v", generated, i+1, got[i], exp[i])
+			}
+		}
+	}
+}
+
+func TestReadTraceInvalid(t *testing.T) {
+	in := `{"event":"expand","seq":1,"key":"S","depth":0,"g":0,"h":2,"f":2}
+
+{"event":"expand",`
+	_, err := search.ReadTrace(strings.NewReader(in))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3:") {
+		t.Errorf("error %v, want one for line 3", err)
+	}
+}
@@ -0,0 +1,110 @@
+// variants.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Searches that give up finding the cheapest goal to find a goal faster on big state spaces
+// Greedy only looks at Away, Weighted counts Away w times (the cost found is at most w times the cheapest if Away
+// never overestimates) and Beam only keeps the best states of every depth
+package search
+
+import (
+	"context"
+	"sort"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// beam is the frontier of beam search
+// The states are expanded depth by depth, the descendants are collected in next and when all the states of cur
+// have been expanded only the width best of next (the lowest prio) are kept and the rest are dropped
+// The states dropped are counted in stats, those of the search
+type beam struct {
+	width int
+	prio  func(src.SearchF) float64
+	cur   []*node
+	next  []*node
+	seq   int
+	stats *Stats
+}
+
+// newBeam returns the frontier of beam search keeping width states of every depth, ordered by Cost plus Away
+func newBeam(width int, stats *Stats) *beam {
+	if width < 1 {
+		width = 1
+	}
+	return &beam{width: width, prio: func(st src.SearchF) float64 { return st.Cost() + st.Away() }, stats: stats}
+}
+
+func (b *beam) size() int { return len(b.cur) + len(b.next) }
+func (b *beam) push(n *node) {
+	b.seq++
+	n.seq = b.seq
+	n.prio = b.prio(n.state)
+	b.next = append(b.next, n)
+}
+func (b *beam) pop() *node {
+	if len(b.cur) == 0 {
+		sort.Slice(b.next, func(i, j int) bool {
+			if b.next[i].prio != b.next[j].prio {
+				return b.next[i].prio < b.next[j].prio
+			}
+			return b.next[i].seq < b.next[j].seq
+		})
+		if len(b.next) > b.width {
+			for _, n := range b.next[b.width:] {
+				b.stats.Pruned++
+				b.stats.Memory -= StateSize + int64(len(n.key))
+			}
+			b.next = b.next[:b.width]
+		}
+		b.cur, b.next = b.next, nil
+	}
+	n := b.cur[0]
+	b.cur[0] = nil
+	b.cur = b.cur[1:]
+	return n
+}
+
+// GreedyContext is greedy best first search (the lowest Away first) that can be stopped
+// It goes straight for the goal but the cost found can be far from the cheapest
+func GreedyContext(ctx context.Context, start src.SearchF, limits Limits) (*Result, error) {
+	return run(ctx, start, limits, &Result{}, &priority{prio: func(st src.SearchF) float64 { return st.Away() }})
+}
+
+// WeightedContext is weighted A* (the lowest Cost plus w times Away first) that can be stopped
+// The Bound of the result is w (1 if w is smaller), the cost found is at most that many times the cheapest if
+// Away never overestimates
+func WeightedContext(ctx context.Context, start src.SearchF, w float64, limits Limits) (*Result, error) {
+	res, err := run(ctx, start, limits, &Result{}, &priority{prio: func(st src.SearchF) float64 { return st.Cost() + w*st.Away() }})
+	res.Bound = w
+	if w < 1 {
+		res.Bound = 1
+	}
+	return res, err
+}
+
+// BeamContext is beam search that can be stopped
+// It expands the states depth by depth like BreadthFirstSearch but only keeps the width states with the lowest
+// Cost plus Away of every depth, the states dropped are counted in Pruned
+// It may not find a goal even if there is one
+func BeamContext(ctx context.Context, start src.SearchF, width int, limits Limits) (*Result, error) {
+	res := &Result{}
+	return run(ctx, start, limits, res, newBeam(width, &res.Stats))
+}
+
+// Greedy does greedy best first search
+func Greedy(start src.SearchF) *Result {
+	res, _ := GreedyContext(context.Background(), start, Limits{})
+	return res
+}
+
+// Weighted does weighted A* with Away counted w times
+func Weighted(start src.SearchF, w float64) *Result {
+	res, _ := WeightedContext(context.Background(), start, w, Limits{})
+	return res
+}
+
+// Beam does beam search keeping width states of every depth
+func Beam(start src.SearchF, width int) *Result {
+	res, _ := BeamContext(context.Background(), start, width, Limits{})
+	return res
+}
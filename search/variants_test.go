@@ -0,0 +1,134 @@
+// variants_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks greedy best first search, the bound of weighted A* and the states beam search prunes
+package search_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/roads"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// accounted checks that every state put on the frontier was expanded, dropped as a duplicate, pruned or is
+// still on the frontier
+func accounted(t *testing.T, name string, res *search.Result) {
+	t.Helper()
+	if 1+res.Generated != res.Expanded+res.Duplicates+res.Pruned+res.Frontier {
+		t.Errorf("%s: 1+%d generated is not %d expanded + %d duplicates + %d pruned + %d on the frontier", name,
+			res.Generated, res.Expanded, res.Duplicates, res.Pruned, res.Frontier)
+	}
+}
+
+// variant is a search with the cost, bound and number of states pruned it is expected to give
+type variant struct {
+	name   string
+	find   func(context.Context, src.SearchF, search.Limits) (*search.Result, error)
+	cost   float64
+	bound  float64
+	pruned int
+}
+
+// The small graph of trace_test.go, where A* finds the cost 3 going through A and B
+func TestVariantsSmall(t *testing.T) {
+	tests := []variant{
+		// Greedy goes to B first as it is closer to G and then straight to G
+		{"greedy", search.GreedyContext, 5, 0, 0},
+		{"weighted 2", func(ctx context.Context, st src.SearchF, limits search.Limits) (*search.Result, error) {
+			return search.WeightedContext(ctx, st, 2, limits)
+		}, 3, 2, 0},
+		{"weighted 0.5", func(ctx context.Context, st src.SearchF, limits search.Limits) (*search.Result, error) {
+			return search.WeightedContext(ctx, st, 0.5, limits)
+		}, 3, 1, 0},
+	}
+	// Beam search with a width of 1 keeps A over B and then B over G at depth 2, with a width of 2 it keeps G
+	// reached from B at depth 2 (f 5) over G reached from A (f 6) and never gets to G at depth 3
+	for _, beam := range []struct {
+		width  int
+		cost   float64
+		pruned int
+	}{{1, 3, 2}, {2, 5, 1}, {3, 5, 0}} {
+		width := beam.width
+		tests = append(tests, variant{fmt.Sprintf("beam %d", width), func(ctx context.Context, st src.SearchF, limits search.Limits) (*search.Result, error) {
+			return search.BeamContext(ctx, st, width, limits)
+		}, beam.cost, 0, beam.pruned})
+	}
+	for _, test := range tests {
+		res, err := test.find(context.Background(), place{"S", 0}, search.Limits{})
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if res.Cost != test.cost || res.Bound != test.bound || res.Pruned != test.pruned {
+			t.Errorf("%s: cost %v, bound %v and %d pruned, expected %v, %v and %d", test.name, res.Cost, res.Bound,
+				res.Pruned, test.cost, test.bound, test.pruned)
+		}
+		accounted(t, test.name, res)
+	}
+}
+
+func TestWeightedBound(t *testing.T) {
+	net := roads.Grid(20, 20, 1)
+	pairs := [][2]string{{"R0C0", "R19C19"}, {"R0C19", "R19C0"}, {"R10C0", "R10C19"}, {"R0C10", "R19C5"}, {"R3C4", "R15C12"}}
+	for _, pair := range pairs {
+		start, err := net.StartDrive(pair[0], pair[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		base, err := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+		if err != nil {
+			t.Fatalf("%s to %s: %v", pair[0], pair[1], err)
+		}
+		for _, w := range []float64{1, 1.5, 2, 5} {
+			name := fmt.Sprintf("%s to %s with weight %g", pair[0], pair[1], w)
+			res, err := search.WeightedContext(context.Background(), start, w, search.Limits{})
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			if res.Bound != w {
+				t.Errorf("%s: bound %v", name, res.Bound)
+			}
+			if res.Cost < base.Cost-1e-6 || res.Cost > w*base.Cost+1e-6 {
+				t.Errorf("%s: cost %.2f is not between the cheapest %.2f and %g times it", name, res.Cost, base.Cost, w)
+			}
+			accounted(t, name, res)
+		}
+		res, err := search.GreedyContext(context.Background(), start, search.Limits{})
+		if err != nil || res.Cost < base.Cost-1e-6 || res.Bound != 0 {
+			t.Errorf("%s to %s greedy: cost %.2f (cheapest %.2f), bound %v, error %v", pair[0], pair[1], res.Cost, base.Cost, res.Bound, err)
+		}
+		accounted(t, pair[0]+" to "+pair[1]+" greedy", res)
+	}
+}
+
+func TestBeamPruned(t *testing.T) {
+	net := roads.Grid(20, 20, 1)
+	start, err := net.StartDrive("R0C0", "R19C19")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := -1
+	for _, width := range []int{1000, 50, 10, 3} {
+		name := fmt.Sprintf("beam %d", width)
+		res, err := search.BeamContext(context.Background(), start, width, search.Limits{})
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		// The 400 cities never fill a beam of 1000, on this network every narrower beam drops more states
+		if width >= 400 && res.Pruned != 0 || width < 400 && res.Pruned <= prev {
+			t.Errorf("%s: %d pruned (%d with the wider beam)", name, res.Pruned, prev)
+		}
+		// The frontier holds the states of the depth kept and their descendants, a city has at most 8 neighbours
+		if res.PeakFrontier > width+8*width {
+			t.Errorf("%s: %d states on the frontier", name, res.PeakFrontier)
+		}
+		prev = res.Pruned
+		accounted(t, name, res)
+	}
+}
@@ -0,0 +1,217 @@
+// searchtrace.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Records what a search does on the city network or the N queens problem to a trace in JSON Lines and summarizes it
+// Every state expanded is written with its depth, g (Cost), h (Away), f (g+h) and the key of its parent
+// The summary has the number of states expanded per depth and per label (the city for the city network and the
+// square of the queen just placed for N queens) and shows where f went down between expansions
+// With -summary an existing trace is only summarized
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/queens"
+	"github.com/hduplooy/gosearch-test/roads"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// The searches by name
+var searches = map[string]func(context.Context, src.SearchF, search.Limits) (*search.Result, error){
+	"depth":    search.DepthFirstContext,
+	"breadth":  search.BreadthFirstContext,
+	"cost":     search.BestCostContext,
+	"costaway": search.BestCostAwayContext,
+}
+
+// queenLabel is the square of the queen placed last, files are letters and ranks are counted from 1
+func queenLabel(st src.SearchF) string {
+	brd := st.(queens.Board)
+	if len(brd.Files) == 0 {
+		return "empty"
+	}
+	return fmt.Sprintf("%c%d", 'a'+brd.Files[len(brd.Files)-1], len(brd.Files))
+}
+
+// record does the search writing the trace to the file
+func record(name string, start src.SearchF, label func(src.SearchF) string, find func(context.Context, src.SearchF, search.Limits) (*search.Result, error), generated bool) error {
+	fl, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	rec := search.NewRecorder(fl)
+	rec.Label = label
+	rec.Generated = generated
+	res, err := find(context.Background(), start, search.Limits{Observer: rec})
+	fmt.Println(res)
+	if err != nil {
+		fmt.Println(err)
+	}
+	for _, st := range res.Path {
+		fmt.Println(label(st))
+	}
+	if err = rec.Flush(); err != nil {
+		fl.Close()
+		return err
+	}
+	return fl.Close()
+}
+
+// count is a label with its number of expansions
+type count struct {
+	label string
+	cnt   int
+	minF  float64
+	maxF  float64
+}
+
+// summarize prints the summary of the trace
+func summarize(trace []search.TraceRecord, top int) {
+	events := make(map[string]int)
+	var depths [][2]int
+	labels := make(map[string]*count)
+	var goal *search.TraceRecord
+	drops, prevF := 0, 0.0
+	for i := range trace {
+		tr := &trace[i]
+		events[tr.Event]++
+		for len(depths) <= tr.Depth {
+			depths = append(depths, [2]int{})
+		}
+		switch tr.Event {
+		case search.TraceExpand:
+			depths[tr.Depth][0]++
+			if events[search.TraceExpand] > 1 && tr.F < prevF-1e-9 {
+				drops++
+			}
+			prevF = tr.F
+			cnt, ok := labels[tr.Label]
+			if !ok {
+				cnt = &count{tr.Label, 0, tr.F, tr.F}
+				labels[tr.Label] = cnt
+			}
+			cnt.cnt++
+			if tr.F < cnt.minF {
+				cnt.minF = tr.F
+			}
+			if tr.F > cnt.maxF {
+				cnt.maxF = tr.F
+			}
+		case search.TraceDuplicate:
+			depths[tr.Depth][1]++
+		case search.TraceGoal:
+			goal = tr
+		}
+	}
+	fmt.Printf("%d expanded, %d duplicates", events[search.TraceExpand], events[search.TraceDuplicate])
+	// The states generated are only in the trace if it was recorded with -generated
+	if events[search.TraceGenerate] > 0 {
+		fmt.Printf(", %d generated", events[search.TraceGenerate])
+	}
+	fmt.Println()
+	if goal != nil {
+		fmt.Printf("Goal %s at depth %d with g %.2f after %d expansions\n", goal.Label, goal.Depth, goal.G, goal.Seq)
+	} else {
+		fmt.Println("No goal in the trace")
+	}
+	fmt.Printf("f went down %d times between expansions\n", drops)
+
+	// The depth histogram with the bars scaled to the most expansions at any depth
+	most := 1
+	for _, val := range depths {
+		if val[0] > most {
+			most = val[0]
+		}
+	}
+	fmt.Printf("\n%5s %9s %10s\n", "Depth", "Expanded", "Duplicates")
+	for i, val := range depths {
+		fmt.Printf("%5d %9d %10d %s\n", i, val[0], val[1], strings.Repeat("#", (val[0]*50+most-1)/most))
+	}
+
+	tmp := make([]*count, 0, len(labels))
+	for _, val := range labels {
+		tmp = append(tmp, val)
+	}
+	sort.Slice(tmp, func(i, j int) bool {
+		if tmp[i].cnt != tmp[j].cnt {
+			return tmp[i].cnt > tmp[j].cnt
+		}
+		return tmp[i].label < tmp[j].label
+	})
+	if top > 0 && top < len(tmp) {
+		tmp = tmp[:top]
+	}
+	fmt.Printf("\n%-15s %9s %10s %10s\n", "Label", "Expanded", "Min f", "Max f")
+	for _, val := range tmp {
+		fmt.Printf("%-15s %9d %10.2f %10.2f\n", val.label, val.cnt, val.minF, val.maxF)
+	}
+}
+
+func main() {
+	domain := flag.String("domain", "city", "city or queens")
+	from := flag.String("from", "Johannesburg", "city to start from")
+	to := flag.String("to", "Cape Town", "city to go to")
+	n := flag.Int("n", 8, "size of the board for queens")
+	algo := flag.String("search", "", "depth, breadth, cost or costaway (costaway for city and depth for queens by default)")
+	name := flag.String("trace", "trace.jsonl", "file the trace is written to")
+	generated := flag.Bool("generated", false, "also record every state generated")
+	summary := flag.String("summary", "", "only summarize this trace")
+	top := flag.Int("top", 20, "most labels to list (0 for all)")
+	flag.Parse()
+
+	if *summary == "" {
+		var start src.SearchF
+		var label func(src.SearchF) string
+		def := "costaway"
+		switch *domain {
+		case "city":
+			trip, err := roads.SouthAfrica().Start(*from, *to)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			start, label = trip, func(st src.SearchF) string { return st.(*roads.Trip).Name }
+		case "queens":
+			if *n < 1 {
+				fmt.Fprintln(os.Stderr, "The board must have at least 1 rank")
+				os.Exit(2)
+			}
+			start, label, def = queens.NewBoard(*n), queenLabel, "depth"
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown domain %q\n", *domain)
+			os.Exit(2)
+		}
+		if *algo == "" {
+			*algo = def
+		}
+		find, ok := searches[*algo]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Unknown search %q\n", *algo)
+			os.Exit(2)
+		}
+		if err := record(*name, start, label, find, *generated); err != nil {
+			fmt.Fprintln(os.Stderr, "Writing the trace:", err)
+			os.Exit(1)
+		}
+		fmt.Println()
+		*summary = *name
+	}
+	fl, err := os.Open(*summary)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	trace, err := search.ReadTrace(fl)
+	fl.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid trace:", err)
+		os.Exit(2)
+	}
+	summarize(trace, *top)
+}
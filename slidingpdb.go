@@ -0,0 +1,206 @@
+// slidingpdb.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Implements A* (BestCostAwayContext of the search package) on the 15-puzzle with additive pattern databases as Away
+// It is similar to slidingtile.go but the pattern databases are far better estimates than Manhattan distance
+// The databases are built once with a backwards breadth first search, saved to a file and loaded from it after that
+// The puzzles are read from a file (like korf10.txt) and with -compare they are also solved with linear conflicts
+// to show how many fewer states have to be expanded
+// The 7-8 partitioning is -patterns 1,4,5,8,9,12,13/2,3,6,7,10,11,14,15 (with a -db file of its own), see README.md
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hduplooy/gosearch-test/search"
+	"github.com/hduplooy/gosearch-test/tiles"
+)
+
+// instance is a puzzle to solve with the length of its optimal solution (-1 if not known)
+type instance struct {
+	tiles []byte
+	moves int
+}
+
+// readInstances reads the puzzles from a file, one per line with the tiles and optionally the optimal length
+// Empty lines and lines starting with # are skipped
+func readInstances(name string, n int) ([]instance, error) {
+	fl, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fl.Close()
+	var tmp []instance
+	scan := bufio.NewScanner(fl)
+	for line := 1; scan.Scan(); line++ {
+		text := strings.TrimSpace(scan.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		inst := instance{moves: -1}
+		if len(fields) == n*n+1 {
+			if inst.moves, err = strconv.Atoi(fields[n*n]); err != nil {
+				return nil, fmt.Errorf("line %d: invalid solution length", line)
+			}
+			fields = fields[:n*n]
+		}
+		if inst.tiles, err = tiles.Parse(strings.Join(fields, " ")); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		tmp = append(tmp, inst)
+	}
+	return tmp, scan.Err()
+}
+
+// parsePatterns reads patterns like 1,2,3/4,5,6
+func parsePatterns(text string) ([][]byte, error) {
+	var tmp [][]byte
+	for _, part := range strings.Split(text, "/") {
+		var pat []byte
+		for _, val := range strings.Split(part, ",") {
+			num, err := strconv.Atoi(strings.TrimSpace(val))
+			if err != nil || num < 1 || num > 255 {
+				return nil, fmt.Errorf("invalid tile %q in pattern", val)
+			}
+			pat = append(pat, byte(num))
+		}
+		tmp = append(tmp, pat)
+	}
+	return tmp, nil
+}
+
+// samePDB is true if the pattern database loaded is for the same goal and patterns
+func samePDB(db *tiles.PDB, goal []byte, patterns [][]byte) bool {
+	if string(db.Goal) != string(goal) || len(db.Patterns) != len(patterns) {
+		return false
+	}
+	for i, pat := range patterns {
+		if string(db.Patterns[i]) != string(pat) {
+			return false
+		}
+	}
+	return true
+}
+
+// loadOrBuild loads the pattern database from the file or builds it (and saves it) if the file is not usable
+func loadOrBuild(name string, game *tiles.Game, patterns [][]byte) (*tiles.PDB, error) {
+	if fl, err := os.Open(name); err == nil {
+		db, err := tiles.LoadPDB(bufio.NewReader(fl))
+		fl.Close()
+		if err == nil && samePDB(db, game.Goal, patterns) {
+			fmt.Printf("Pattern database loaded from %s\n", name)
+			return db, nil
+		}
+	}
+	start := time.Now()
+	db, err := tiles.BuildPDB(game, patterns)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("Pattern database built in %v\n", time.Since(start))
+	fl, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Save(fl); err != nil {
+		fl.Close()
+		return nil, err
+	}
+	return db, fl.Close()
+}
+
+// solve solves the puzzle with the heuristic and returns the result of the search and the number of moves
+func solve(goal, tls []byte, h tiles.Heuristic) (*search.Result, int, error) {
+	game, err := tiles.NewGame(4, goal, h)
+	if err != nil {
+		return nil, 0, err
+	}
+	start, err := game.Start(tls)
+	if err != nil {
+		return nil, 0, err
+	}
+	res, _ := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+	return res, res.Goal.(*tiles.State).Moves, nil
+}
+
+func main() {
+	goalText := flag.String("goal", "0 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15", "goal order of the tiles")
+	patText := flag.String("patterns", "1,2,3,4,5,6/7,8,9,10,11,12/13,14,15", "disjoint patterns, for example 1,4,5,8,9,12,13/2,3,6,7,10,11,14,15 for 7-8")
+	dbName := flag.String("db", "pdb663.bin", "file the pattern database is loaded from or saved to")
+	instName := flag.String("instances", "korf10.txt", "file with the puzzles to solve")
+	most := flag.Int("n", 0, "only solve this many puzzles (0 for all)")
+	compare := flag.Bool("compare", false, "also solve with linear conflicts and compare the steps")
+	flag.Parse()
+
+	goal, err := tiles.Parse(*goalText)
+	if err == nil && len(goal) != 16 {
+		err = fmt.Errorf("the goal must be for the 15-puzzle")
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid goal:", err)
+		os.Exit(2)
+	}
+	patterns, err := parsePatterns(*patText)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	insts, err := readInstances(*instName, 4)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if *most > 0 && *most < len(insts) {
+		insts = insts[:*most]
+	}
+	game, _ := tiles.NewGame(4, goal, nil)
+	db, err := loadOrBuild(*dbName, game, patterns)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Pattern database:", err)
+		os.Exit(1)
+	}
+
+	fine := true
+	totPDB, totLC := 0, 0
+	fmt.Printf("%4s %6s %12s %12s", "Nr", "Moves", "PDB steps", "Time")
+	if *compare {
+		fmt.Printf(" %12s %12s %8s", "LC steps", "Time", "Ratio")
+	}
+	fmt.Println()
+	for i, inst := range insts {
+		res, moves, err := solve(goal, inst.tiles, db.Heuristic)
+		if err != nil {
+			fmt.Printf("%4d %v\n", i+1, err)
+			fine = false
+			continue
+		}
+		totPDB += res.Expanded
+		fmt.Printf("%4d %6d %12d %12v", i+1, moves, res.Expanded, res.Time.Round(time.Millisecond))
+		if *compare {
+			res2, _, _ := solve(goal, inst.tiles, tiles.LinearConflict)
+			totLC += res2.Expanded
+			fmt.Printf(" %12d %12v %8.1f", res2.Expanded, res2.Time.Round(time.Millisecond), float64(res2.Expanded)/float64(res.Expanded))
+		}
+		if inst.moves >= 0 && moves != inst.moves {
+			fmt.Printf(" expected %d moves", inst.moves)
+			fine = false
+		}
+		fmt.Println()
+	}
+	fmt.Printf("Total PDB steps %d", totPDB)
+	if *compare {
+		fmt.Printf(", linear conflict steps %d (%.1f times more)", totLC, float64(totLC)/float64(totPDB))
+	}
+	fmt.Println()
+	if !fine {
+		os.Exit(1)
+	}
+}
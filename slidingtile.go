@@ -0,0 +1,85 @@
+// slidingtile.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Implements A* on the sliding tile puzzles (8-puzzle, 15-puzzle, ...) with BestCostAwayContext of the search
+// package, which closes the boards expanded so that moving a tile back and forth is not searched
+// Cost is the number of moves made and Away is either the Manhattan distance or Manhattan with linear conflicts
+// Because neither overestimates the moves needed the solution found is optimal
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/hduplooy/gosearch-test/search"
+	"github.com/hduplooy/gosearch-test/tiles"
+)
+
+// heuristics that can be chosen with -h
+var heuristics = map[string]tiles.Heuristic{
+	"none":      tiles.None,
+	"manhattan": tiles.Manhattan,
+	"linear":    tiles.LinearConflict,
+}
+
+// solve searches for the optimal solution of the puzzle
+// It returns the result of the search (with all the states from the start to the goal) and the moves of the blank
+func solve(tls []byte, h tiles.Heuristic) (*search.Result, string, error) {
+	n := 0
+	for n*n < len(tls) {
+		n++
+	}
+	game, err := tiles.NewGame(n, tiles.DefaultGoal(n), h)
+	if err != nil {
+		return nil, "", err
+	}
+	start, err := game.Start(tls)
+	if err != nil {
+		return nil, "", err
+	}
+	res, _ := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+	return res, tiles.Path(res.History(), res.Goal), nil
+}
+
+func main() {
+	board := flag.String("board", "8 6 7 2 5 4 3 0 1", "tiles row by row with 0 for the blank")
+	file := flag.String("file", "", "text file with the tiles")
+	hname := flag.String("h", "linear", "heuristic: none, manhattan or linear")
+	show := flag.Bool("print", false, "print the puzzle after every move")
+	flag.Parse()
+
+	h, ok := heuristics[*hname]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown heuristic %q\n", *hname)
+		os.Exit(2)
+	}
+	text := *board
+	if *file != "" {
+		buf, err := ioutil.ReadFile(*file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		text = string(buf)
+	}
+	tls, err := tiles.Parse(text)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid puzzle:", err)
+		os.Exit(2)
+	}
+	res, path, err := solve(tls, h)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println(res)
+	fmt.Printf("Solved in %d moves of the blank: %s\n", len(path), path)
+	if *show {
+		for _, val := range res.Path {
+			fmt.Print(val)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// sokoban.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Implements A* on Sokoban levels in the XSB format with BestCostAwayContext of the search package, which drops
+// the positions of the boxes (and reach of the player) already expanded
+// Away is the least pushes to get every box onto its own goal (a matching of the boxes to the goals)
+// By default the search is greedy on Away alone which is fast but not optimal, with -optimal the cost is the
+// number of pushes so that the solution has the fewest pushes
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hduplooy/gosearch-test/search"
+	"github.com/hduplooy/gosearch-test/sokoban"
+)
+
+// solve searches for a solution of the level and returns the result of the search and the moves ("" if there is none)
+func solve(start *sokoban.State, show bool) (*search.Result, string) {
+	res, _ := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+	if res.Goal == nil {
+		return res, ""
+	}
+	if show {
+		for _, st := range res.Path {
+			fmt.Println(st)
+		}
+	}
+	return res, sokoban.Moves(res.History(), res.Goal)
+}
+
+func main() {
+	file := flag.String("file", "sokoban.xsb", "XSB file with the levels")
+	level := flag.Int("level", 0, "only solve this level (counted from 1, 0 for all)")
+	optimal := flag.Bool("optimal", false, "find the solution with the fewest pushes")
+	show := flag.Bool("print", false, "print the level after every push")
+	flag.Parse()
+
+	fl, err := os.Open(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	levels, err := sokoban.Load(fl)
+	fl.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid level:", err)
+		os.Exit(2)
+	}
+	if *level > 0 {
+		if *level > len(levels) {
+			fmt.Fprintf(os.Stderr, "There are only %d levels\n", len(levels))
+			os.Exit(2)
+		}
+		levels = levels[*level-1 : *level]
+	}
+	fine := true
+	for _, start := range levels {
+		start.Optimal = *optimal
+		fmt.Println(start.Name)
+		if !*show {
+			fmt.Print(start)
+		}
+		res, moves := solve(start, *show)
+		fmt.Println(res)
+		if moves == "" && start.Done() {
+			fmt.Println("Solved already")
+			continue
+		}
+		if moves == "" {
+			fmt.Println("No solution exists")
+			fine = false
+			continue
+		}
+		pushes, err := start.Replay(moves)
+		if err != nil {
+			fmt.Println("Invalid solution:", err)
+			fine = false
+			continue
+		}
+		fmt.Printf("Solved in %d pushes and %d moves: %s\n", pushes, len(moves), moves)
+		fmt.Println()
+	}
+	if !fine {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,216 @@
+// level.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Loading Sokoban levels in the XSB text format and working out what does not change while solving them
+// In XSB # is a wall, a space (or - or _) the floor, . a goal, $ a box, * a box on a goal, @ the player and + the
+// player on a goal. A file can hold many levels separated by empty lines or comments (lines starting with ;)
+package sokoban
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Level holds everything shared by all the states of a level
+// Name is the comment or title given before the level
+// Width and Height is the size of the level including a border of walls around it
+// With Optimal the cost of a state is the number of pushes so that BestCostAwaySearch finds the fewest pushes,
+// otherwise it is 0 and the search is greedy on the heuristic alone
+// walls and goals mark the squares (y*Width+x), every square outside the reach of the player is a wall
+// dead marks the squares from where a box can never be pushed onto any goal
+// dist has for every goal the least pushes needed to get a box from every square onto it (-1 if it can not)
+// steps are the offsets to the neighbours left, right, up and down
+type Level struct {
+	Name    string
+	Width   int
+	Height  int
+	Optimal bool
+	walls   []bool
+	goals   []bool
+	dead    []bool
+	dist    [][]int
+	steps   [4]int
+}
+
+// xsb is true if the line is part of a level
+func xsb(line string) bool {
+	if !strings.Contains(line, "#") {
+		return false
+	}
+	for _, ch := range line {
+		if !strings.ContainsRune("#@+$*. -_", ch) {
+			return false
+		}
+	}
+	return true
+}
+
+// Load reads all the levels and returns their start states
+func Load(r io.Reader) ([]*State, error) {
+	tmp := make([]*State, 0)
+	var lines []string
+	name := ""
+	// add makes a level of the lines read so far
+	add := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		if name == "" {
+			name = fmt.Sprintf("Level %d", len(tmp)+1)
+		}
+		st, err := newLevel(name, lines)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		tmp = append(tmp, st)
+		lines, name = nil, ""
+		return nil
+	}
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		line := strings.TrimRight(scan.Text(), " \t\r")
+		if xsb(line) {
+			lines = append(lines, line)
+			continue
+		}
+		if err := add(); err != nil {
+			return nil, err
+		}
+		// A comment or title before a level is its name
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, ";"):
+			name = strings.TrimSpace(line[1:])
+		case strings.HasPrefix(line, "Title:"):
+			name = strings.TrimSpace(line[6:])
+		}
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+	if err := add(); err != nil {
+		return nil, err
+	}
+	if len(tmp) == 0 {
+		return nil, errors.New("no levels found")
+	}
+	return tmp, nil
+}
+
+// newLevel sets up the level given by the lines and returns its start state
+func newLevel(name string, lines []string) (*State, error) {
+	width := 0
+	for _, line := range lines {
+		if len(line) > width {
+			width = len(line)
+		}
+	}
+	// A border of walls is added so that no square is on the edge
+	width += 2
+	height := len(lines) + 2
+	lvl := &Level{Name: name, Width: width, Height: height, walls: make([]bool, width*height),
+		goals: make([]bool, width*height), steps: [4]int{-1, 1, -width, width}}
+	for i := range lvl.walls {
+		lvl.walls[i] = true
+	}
+	player := -1
+	boxes := make([]int, 0)
+	goals := 0
+	for y, line := range lines {
+		for x, ch := range line {
+			pos := (y+1)*width + x + 1
+			if ch == '#' {
+				continue
+			}
+			lvl.walls[pos] = false
+			if ch == '.' || ch == '*' || ch == '+' {
+				lvl.goals[pos] = true
+				goals++
+			}
+			if ch == '$' || ch == '*' {
+				boxes = append(boxes, pos)
+			}
+			if ch == '@' || ch == '+' {
+				if player >= 0 {
+					return nil, errors.New("more than one player")
+				}
+				player = pos
+			}
+		}
+	}
+	if player < 0 {
+		return nil, errors.New("no player")
+	}
+	if len(boxes) == 0 || len(boxes) != goals {
+		return nil, fmt.Errorf("%d boxes for %d goals", len(boxes), goals)
+	}
+	// Everything the player can not get to (ignoring the boxes) is outside the level
+	inside := make([]bool, len(lvl.walls))
+	inside[player] = true
+	queue := []int{player}
+	for len(queue) > 0 {
+		pos := queue[0]
+		queue = queue[1:]
+		for _, step := range lvl.steps {
+			if nxt := pos + step; !lvl.walls[nxt] && !inside[nxt] {
+				inside[nxt] = true
+				queue = append(queue, nxt)
+			}
+		}
+	}
+	for pos := range lvl.walls {
+		if !inside[pos] {
+			if lvl.goals[pos] {
+				return nil, errors.New("a goal is outside the reach of the player")
+			}
+			lvl.walls[pos] = true
+		}
+	}
+	for _, box := range boxes {
+		if lvl.walls[box] {
+			return nil, errors.New("a box is outside the reach of the player")
+		}
+	}
+	lvl.distances()
+	return lvl.start(boxes, player), nil
+}
+
+// distances works out dist and dead by pulling a box away from every goal
+// A box at pos can be pushed to pos+step if the player can stand at pos-step, so going backwards from the goal
+// a box at pos can come from pos-step if both pos-step and pos-2*step are floor
+func (lvl *Level) distances() {
+	lvl.dist = make([][]int, 0)
+	lvl.dead = make([]bool, len(lvl.walls))
+	for pos := range lvl.walls {
+		lvl.dead[pos] = !lvl.walls[pos]
+	}
+	for goal, isGoal := range lvl.goals {
+		if !isGoal {
+			continue
+		}
+		dist := make([]int, len(lvl.walls))
+		for i := range dist {
+			dist[i] = -1
+		}
+		dist[goal] = 0
+		lvl.dead[goal] = false
+		queue := []int{goal}
+		for len(queue) > 0 {
+			pos := queue[0]
+			queue = queue[1:]
+			for _, step := range lvl.steps {
+				from := pos - step
+				if lvl.walls[from] || lvl.walls[from-step] || dist[from] >= 0 {
+					continue
+				}
+				dist[from] = dist[pos] + 1
+				lvl.dead[from] = false
+				queue = append(queue, from)
+			}
+		}
+		lvl.dist = append(lvl.dist, dist)
+	}
+}
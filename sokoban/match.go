@@ -0,0 +1,80 @@
+// match.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// The heuristic for Sokoban, every box is matched to its own goal so that the total pushes are the least
+package sokoban
+
+// noMatch is the cost used for a box that can not reach a goal
+const noMatch = 1 << 20
+
+// matching returns the least total cost of assigning every row (box) to its own column (goal) with the
+// Hungarian method, the result is noMatch or more if it can not be done
+func matching(cost [][]int) int {
+	n := len(cost)
+	// u and v are the potentials of the rows and columns, way and match are for the columns (1 based)
+	u, v := make([]int, n+1), make([]int, n+1)
+	match, way := make([]int, n+1), make([]int, n+1)
+	minv, used := make([]int, n+1), make([]bool, n+1)
+	for row := 1; row <= n; row++ {
+		match[0] = row
+		col := 0
+		for j := range minv {
+			minv[j] = 1 << 30
+			used[j] = false
+		}
+		for match[col] != 0 {
+			used[col] = true
+			cur, delta, next := match[col], 1<<30, 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if val := cost[cur-1][j-1] - u[cur] - v[j]; val < minv[j] {
+					minv[j] = val
+					way[j] = col
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					next = j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[match[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			col = next
+		}
+		// Follow the augmenting path back
+		for col != 0 {
+			prev := way[col]
+			match[col] = match[prev]
+			col = prev
+		}
+	}
+	tot := 0
+	for j := 1; j <= n; j++ {
+		tot += cost[match[j]-1][j-1]
+	}
+	return tot
+}
+
+// heuristic returns the least pushes needed to get every box onto its own goal ignoring the other boxes
+// It is noMatch or more if some box can not get to any goal left for it
+func (lvl *Level) heuristic(boxes []int) int {
+	cost := make([][]int, len(boxes))
+	for i, box := range boxes {
+		cost[i] = make([]int, len(lvl.dist))
+		for j, dist := range lvl.dist {
+			if dist[box] < 0 {
+				cost[i][j] = noMatch
+			} else {
+				cost[i][j] = dist[box]
+			}
+		}
+	}
+	return matching(cost)
+}
@@ -0,0 +1,313 @@
+// sokoban.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Package sokoban implements the Sokoban puzzle as states for hduplooy/gosearch
+// The player must push all the boxes onto the goals, a box can only be pushed (not pulled) and only one at a time
+// A state is a push, so the player positions between pushes are not states of their own: a state is keyed by
+// the boxes and the region the player can reach without pushing
+// Pushes that lead to a simple deadlock (a box on a square from where it can never reach a goal) or a freeze deadlock
+// (boxes that can never move again not all on goals) are not generated
+package sokoban
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// State has the positions of the boxes (sorted) and the player after Pushes pushes
+// h is the heuristic (the matching of the boxes to the goals) and key is kept once worked out
+type State struct {
+	*Level
+	Boxes  []int
+	Player int
+	Pushes int
+	h      int
+	key    string
+}
+
+// start returns the start state for the boxes and player
+func (lvl *Level) start(boxes []int, player int) *State {
+	sort.Ints(boxes)
+	return &State{lvl, boxes, player, 0, lvl.heuristic(boxes), ""}
+}
+
+// occupied marks the squares with boxes
+func (st *State) occupied() []bool {
+	tmp := make([]bool, len(st.walls))
+	for _, box := range st.Boxes {
+		tmp[box] = true
+	}
+	return tmp
+}
+
+// reach returns the squares the player can get to without pushing a box
+// way has for every square reached the step taken to get there, it is used to find the moves of the player
+func (st *State) reach(occ []bool) (reached []bool, way []int) {
+	reached = make([]bool, len(st.walls))
+	way = make([]int, len(st.walls))
+	reached[st.Player] = true
+	queue := []int{st.Player}
+	for len(queue) > 0 {
+		pos := queue[0]
+		queue = queue[1:]
+		for _, step := range st.steps {
+			nxt := pos + step
+			if !reached[nxt] && !st.walls[nxt] && !occ[nxt] {
+				reached[nxt] = true
+				way[nxt] = step
+				queue = append(queue, nxt)
+			}
+		}
+	}
+	return reached, way
+}
+
+// frozen is true if the box at pos can never be moved again, neither horizontally nor vertically
+// The boxes marked in stuck are being checked already and are treated as walls
+// If a box found frozen is not on a goal, off is set
+func (lvl *Level) frozen(occ, stuck []bool, pos int, off *bool) bool {
+	stuck[pos] = true
+	res := lvl.blocked(occ, stuck, pos, 1, off) && lvl.blocked(occ, stuck, pos, lvl.Width, off)
+	stuck[pos] = false
+	if res && !lvl.goals[pos] {
+		*off = true
+	}
+	return res
+}
+
+// blocked is true if the box at pos can not be moved along the axis of step
+// That is if there is a wall on either side, dead squares on both sides or a box on either side that is frozen
+func (lvl *Level) blocked(occ, stuck []bool, pos, step int, off *bool) bool {
+	a, b := pos-step, pos+step
+	if lvl.walls[a] || lvl.walls[b] || stuck[a] || stuck[b] {
+		return true
+	}
+	if lvl.dead[a] && lvl.dead[b] {
+		return true
+	}
+	return (occ[a] && lvl.frozen(occ, stuck, a, off)) || (occ[b] && lvl.frozen(occ, stuck, b, off))
+}
+
+// Descendants returns every push the player can make that does not lead to a deadlock
+func (st *State) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0)
+	occ := st.occupied()
+	reached, _ := st.reach(occ)
+	stuck := make([]bool, len(st.walls))
+	for i, box := range st.Boxes {
+		for _, step := range st.steps {
+			to := box + step
+			if !reached[box-step] || st.walls[to] || occ[to] || st.dead[to] {
+				continue
+			}
+			occ[box], occ[to] = false, true
+			off := false
+			if !st.frozen(occ, stuck, to, &off) || !off {
+				boxes := make([]int, len(st.Boxes))
+				copy(boxes, st.Boxes)
+				boxes[i] = to
+				sort.Ints(boxes)
+				// If the boxes can not all be matched to goals it is a deadlock too
+				if h := st.heuristic(boxes); h < noMatch {
+					tmp = append(tmp, &State{st.Level, boxes, box, st.Pushes + 1, h, ""})
+				}
+			}
+			occ[box], occ[to] = true, false
+		}
+	}
+	return tmp
+}
+
+// Done is true when all the boxes are on goals
+func (st *State) Done() bool {
+	for _, box := range st.Boxes {
+		if !st.goals[box] {
+			return false
+		}
+	}
+	return true
+}
+
+// Cost is the number of pushes if Optimal, otherwise 0
+func (st *State) Cost() float64 {
+	if st.Optimal {
+		return float64(st.Pushes)
+	}
+	return 0.0
+}
+
+// Away is the least pushes needed to match every box to its own goal
+func (st *State) Away() float64 { return float64(st.h) }
+
+// Key is the top left square the player can reach followed by the boxes
+func (st *State) Key() string {
+	if st.key != "" {
+		return st.key
+	}
+	reached, _ := st.reach(st.occupied())
+	player := st.Player
+	for pos, ok := range reached {
+		if ok {
+			player = pos
+			break
+		}
+	}
+	tmp := make([]byte, 0, 3*(len(st.Boxes)+1))
+	for _, pos := range append([]int{player}, st.Boxes...) {
+		tmp = append(tmp, byte(pos>>16), byte(pos>>8), byte(pos))
+	}
+	st.key = string(tmp)
+	return st.key
+}
+
+// outside is true if the wall at pos has no floor around it, it is not drawn
+func (lvl *Level) outside(pos int) bool {
+	for dy := -lvl.Width; dy <= lvl.Width; dy += lvl.Width {
+		for dx := -1; dx <= 1; dx++ {
+			if nxt := pos + dy + dx; nxt >= 0 && nxt < len(lvl.walls) && !lvl.walls[nxt] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// String draws the level in XSB
+func (st *State) String() string {
+	occ := st.occupied()
+	var buf strings.Builder
+	for y := 1; y < st.Height-1; y++ {
+		line := make([]byte, 0, st.Width)
+		for x := 1; x < st.Width-1; x++ {
+			pos := y*st.Width + x
+			ch := byte(' ')
+			switch {
+			case st.walls[pos] && st.outside(pos):
+			case st.walls[pos]:
+				ch = '#'
+			case pos == st.Player:
+				ch = '@'
+				if st.goals[pos] {
+					ch = '+'
+				}
+			case occ[pos]:
+				ch = '$'
+				if st.goals[pos] {
+					ch = '*'
+				}
+			case st.goals[pos]:
+				ch = '.'
+			}
+			line = append(line, ch)
+		}
+		buf.WriteString(strings.TrimRight(string(line), " "))
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+// letter returns the letter of the move in direction step, lower case for a walk and upper case for a push
+func (lvl *Level) letter(step int, push bool) byte {
+	var ch byte
+	switch step {
+	case -1:
+		ch = 'l'
+	case 1:
+		ch = 'r'
+	case -lvl.Width:
+		ch = 'u'
+	default:
+		ch = 'd'
+	}
+	if push {
+		ch -= 'a' - 'A'
+	}
+	return ch
+}
+
+// walk returns the moves of the player to get to pos without pushing anything
+func (st *State) walk(pos int) []byte {
+	_, way := st.reach(st.occupied())
+	tmp := make([]byte, 0)
+	for ; pos != st.Player; pos -= way[pos] {
+		tmp = append(tmp, st.letter(way[pos], false))
+	}
+	for i, j := 0, len(tmp)-1; i < j; i, j = i+1, j-1 {
+		tmp[i], tmp[j] = tmp[j], tmp[i]
+	}
+	return tmp
+}
+
+// Moves returns the moves of the player in LURD notation (lower case walks and upper case pushes)
+// given the history and answer of a search
+func Moves(hist []src.SearchF, ans src.SearchF) string {
+	states := make([]*State, 0, len(hist)+1)
+	for i := len(hist) - 1; i >= 0; i-- {
+		states = append(states, hist[i].(*State))
+	}
+	states = append(states, ans.(*State))
+	tmp := make([]byte, 0)
+	for i := 1; i < len(states); i++ {
+		prev, cur := states[i-1], states[i]
+		// The box pushed was where the player is now
+		step := 0
+		for _, s := range prev.steps {
+			if contains(cur.Boxes, cur.Player+s) && !contains(prev.Boxes, cur.Player+s) {
+				step = s
+			}
+		}
+		tmp = append(tmp, prev.walk(cur.Player-step)...)
+		tmp = append(tmp, prev.letter(step, true))
+	}
+	return string(tmp)
+}
+
+// contains is true if the sorted list has val
+func contains(list []int, val int) bool {
+	i := sort.SearchInts(list, val)
+	return i < len(list) && list[i] == val
+}
+
+// Replay plays the moves in LURD notation from the state and checks that they are legal and solve the level
+// It returns the number of pushes
+func (st *State) Replay(moves string) (int, error) {
+	occ := st.occupied()
+	player, pushes := st.Player, 0
+	for i := 0; i < len(moves); i++ {
+		var step int
+		switch moves[i] {
+		case 'l', 'L':
+			step = -1
+		case 'r', 'R':
+			step = 1
+		case 'u', 'U':
+			step = -st.Width
+		case 'd', 'D':
+			step = st.Width
+		default:
+			return pushes, fmt.Errorf("invalid move %q", moves[i])
+		}
+		nxt := player + step
+		if st.walls[nxt] {
+			return pushes, fmt.Errorf("move %d walks into a wall", i+1)
+		}
+		if occ[nxt] {
+			if st.walls[nxt+step] || occ[nxt+step] {
+				return pushes, fmt.Errorf("move %d pushes a box that can not move", i+1)
+			}
+			occ[nxt], occ[nxt+step] = false, true
+			pushes++
+		}
+		player = nxt
+	}
+	for pos, box := range occ {
+		if box && !st.goals[pos] {
+			return pushes, errors.New("not all the boxes are on goals")
+		}
+	}
+	return pushes, nil
+}
@@ -0,0 +1,91 @@
+// sokoban_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks the fewest pushes found for the small levels of sokoban.xsb and that the solutions replay
+package sokoban_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/search"
+	"github.com/hduplooy/gosearch-test/sokoban"
+)
+
+// The fewest pushes for the small levels in sokoban.xsb
+// Level 8 (XSokoban #1, 97 pushes) takes about 20s and is left to the sokoban program
+var known = map[string]int{
+	"Level 1": 8,
+	"Level 2": 3,
+	"Level 3": 13,
+	"Level 4": 7,
+	"Level 5": 6,
+	"Level 6": 8,
+	"Level 7": 12,
+}
+
+// levels loads the levels of sokoban.xsb that are in known
+func levels(t *testing.T) []*sokoban.State {
+	t.Helper()
+	fl, err := os.Open("../sokoban.xsb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fl.Close()
+	all, err := sokoban.Load(fl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tmp []*sokoban.State
+	for _, st := range all {
+		if _, ok := known[st.Name]; ok {
+			tmp = append(tmp, st)
+		}
+	}
+	if len(tmp) != len(known) {
+		t.Fatalf("found %d of the %d levels", len(tmp), len(known))
+	}
+	return tmp
+}
+
+func TestFewestPushes(t *testing.T) {
+	for _, optimal := range []bool{true, false} {
+		for _, start := range levels(t) {
+			start.Optimal = optimal
+			res, _ := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+			if res.Goal == nil {
+				t.Errorf("%s (optimal %v): no solution found", start.Name, optimal)
+				continue
+			}
+			moves := sokoban.Moves(res.History(), res.Goal)
+			pushes, err := start.Replay(moves)
+			if err != nil {
+				t.Errorf("%s (optimal %v): %s does not replay: %v", start.Name, optimal, moves, err)
+				continue
+			}
+			// The greedy search only has to find a solution, not the one with the fewest pushes
+			if want := known[start.Name]; pushes < want || optimal && pushes != want {
+				t.Errorf("%s (optimal %v): solved in %d pushes, the fewest is %d", start.Name, optimal, pushes, want)
+			}
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		level string
+	}{
+		{"empty", "; nothing here\n"},
+		{"no player", "#####\n#$. #\n#####\n"},
+		{"boxes and goals", "#####\n#@$$.#\n#####\n"},
+		{"two players", "######\n#@$.@#\n######\n"},
+	}
+	for _, test := range tests {
+		if _, err := sokoban.Load(strings.NewReader(test.level)); err == nil {
+			t.Errorf("%s: loaded without an error", test.name)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// sudoku.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Solves Sudoku puzzles (9X9 and bigger like 16X16) searching over the states in the sudoku package
+// Singles are filled in before branching on the cell with the fewest candidates
+// All solutions are counted (up to -max) so that puzzles without a unique solution are detected
+// With -workers more than 1 the solutions are counted by goroutines sharing out the subtrees (EnumerateParallel)
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/search"
+	"github.com/hduplooy/gosearch-test/sudoku"
+)
+
+func main() {
+	grid := flag.String("grid", "8..........36......7..9.2...5...7.......457.....1...3...1....68..85...1..9....4..", "the puzzle row by row with . for empty cells")
+	file := flag.String("file", "", "text file with the puzzle")
+	most := flag.Int("max", 2, "stop after finding this many solutions (0 to count them all)")
+	workers := flag.Int("workers", 1, "number of goroutines counting the solutions")
+	flag.Parse()
+
+	var box int
+	var cells []int
+	var err error
+	if *file != "" {
+		var fl *os.File
+		if fl, err = os.Open(*file); err == nil {
+			box, cells, err = sudoku.Parse(fl)
+			fl.Close()
+		}
+	} else {
+		box, cells, err = sudoku.Parse(strings.NewReader(*grid))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid puzzle:", err)
+		os.Exit(2)
+	}
+	start, err := sudoku.New(box, cells)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Print(start)
+
+	// Enumerate the solutions keeping the first one
+	var first *sudoku.Grid
+	cnt := 0
+	found := func(ans src.SearchF) bool {
+		if first == nil {
+			first = ans.(*sudoku.Grid)
+		}
+		cnt++
+		return *most == 0 || cnt < *most
+	}
+	var steps int
+	if *workers > 1 {
+		res, err := search.EnumerateParallel(context.Background(), start, *workers, found)
+		if err != nil {
+			fmt.Printf("Stopped after %d steps and %d solutions: %v\n", res.Expanded, cnt, err)
+			os.Exit(1)
+		}
+		steps = res.Expanded
+	} else {
+		steps = search.Enumerate(start, found)
+	}
+	fmt.Printf("Done in %d steps\n", steps)
+	switch {
+	case cnt == 0:
+		fmt.Println("No solution")
+		os.Exit(1)
+	case cnt == 1:
+		fmt.Println("The solution is unique")
+	case cnt == *most:
+		fmt.Printf("Not unique, stopped after %d solutions\n", cnt)
+	default:
+		fmt.Printf("Not unique, %d solutions\n", cnt)
+	}
+	fmt.Print(first)
+	if !first.Valid() {
+		fmt.Println("The solution is not valid")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,349 @@
+// sudoku.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Package sudoku implements Sudoku puzzles of any size (9X9, 16X16, ...) as states for hduplooy/gosearch
+// Every row, column and box of the grid must hold every value exactly once
+// Before branching naked singles (a cell with only one candidate left) and hidden singles (a value with only one
+// cell left in a row, column or box) are filled in, and only the cell with the fewest candidates is branched on
+package sudoku
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// MaxBox is the biggest box size supported, the candidates of a cell are kept as bits of a uint64
+const MaxBox = 8
+
+// Puzzle holds everything shared by all the states of a puzzle
+// Box is the size of a box (3 for the normal 9X9 puzzle) and Size the number of rows, columns and values
+// units has the cells of every row, column and box
+// peers has for every cell the other cells in its row, column and box
+type Puzzle struct {
+	Box   int
+	Size  int
+	units [][]int
+	peers [][]int
+}
+
+// newPuzzle sets up the units and peers for boxes of size box
+func newPuzzle(box int) *Puzzle {
+	n := box * box
+	pz := &Puzzle{Box: box, Size: n}
+	for i := 0; i < n; i++ {
+		row, col, bx := make([]int, n), make([]int, n), make([]int, n)
+		for j := 0; j < n; j++ {
+			row[j] = i*n + j
+			col[j] = j*n + i
+			bx[j] = ((i/box)*box+j/box)*n + (i%box)*box + j%box
+		}
+		pz.units = append(pz.units, row, col, bx)
+	}
+	pz.peers = make([][]int, n*n)
+	for _, unit := range pz.units {
+		for _, c := range unit {
+			for _, p := range unit {
+				if p != c && !contains(pz.peers[c], p) {
+					pz.peers[c] = append(pz.peers[c], p)
+				}
+			}
+		}
+	}
+	return pz
+}
+
+// contains is true if val is in list
+func contains(list []int, val int) bool {
+	for _, tmp := range list {
+		if tmp == val {
+			return true
+		}
+	}
+	return false
+}
+
+// Grid is the state
+// Cells has the value of every cell (row*Size+column) and 0 if it is still empty
+// Cands has for every cell a bit set for every value it can still take (bit v-1 for value v)
+type Grid struct {
+	*Puzzle
+	Cells []int
+	Cands []uint64
+}
+
+// all returns the candidates mask with every value set
+func (g *Grid) all() uint64 {
+	return 1<<uint(g.Size) - 1
+}
+
+// New returns the starting state for the given cells (0 for empty) after filling in all the singles
+// An error is returned if the givens contradict each other
+func New(box int, cells []int) (*Grid, error) {
+	if box < 1 || box > MaxBox {
+		return nil, fmt.Errorf("box size %d is not supported", box)
+	}
+	pz := newPuzzle(box)
+	if len(cells) != pz.Size*pz.Size {
+		return nil, fmt.Errorf("expected %d cells but got %d", pz.Size*pz.Size, len(cells))
+	}
+	g := &Grid{pz, make([]int, len(cells)), make([]uint64, len(cells))}
+	for i := range g.Cands {
+		g.Cands[i] = g.all()
+	}
+	for i, val := range cells {
+		if val == 0 {
+			continue
+		}
+		if val < 0 || val > pz.Size || !g.assign(i, val) {
+			return nil, fmt.Errorf("the given value %d in row %d column %d is not valid", val, i/pz.Size+1, i%pz.Size+1)
+		}
+	}
+	if !g.propagate() {
+		return nil, errors.New("the puzzle has no solution")
+	}
+	return g, nil
+}
+
+// assign puts val in cell and removes it from the candidates of its peers
+// It returns false if that leaves a peer without candidates
+func (g *Grid) assign(cell, val int) bool {
+	bit := uint64(1) << uint(val-1)
+	if g.Cands[cell]&bit == 0 {
+		return false
+	}
+	g.Cells[cell] = val
+	g.Cands[cell] = bit
+	for _, p := range g.peers[cell] {
+		g.Cands[p] &^= bit
+		if g.Cands[p] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// propagate fills in naked and hidden singles until there are none left
+// It returns false if a contradiction is found
+func (g *Grid) propagate() bool {
+	for changed := true; changed; {
+		changed = false
+		// Naked singles: an empty cell with only one candidate left
+		for i, val := range g.Cells {
+			if val == 0 && bits.OnesCount64(g.Cands[i]) == 1 {
+				if !g.assign(i, bits.TrailingZeros64(g.Cands[i])+1) {
+					return false
+				}
+				changed = true
+			}
+		}
+		// Hidden singles: a value that only one cell of a unit can still take
+		for _, unit := range g.units {
+			// once has the values seen in one cell of the unit so far, more those seen in more than one
+			var once, more uint64
+			for _, c := range unit {
+				more |= once & g.Cands[c]
+				once |= g.Cands[c]
+			}
+			if once != g.all() {
+				return false
+			}
+			for single := once &^ more; single != 0; single &= single - 1 {
+				bit := single & -single
+				for _, c := range unit {
+					if g.Cands[c]&bit != 0 && g.Cells[c] == 0 {
+						if !g.assign(c, bits.TrailingZeros64(bit)+1) {
+							return false
+						}
+						changed = true
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// copy returns a copy of the grid that can be changed without changing this one
+func (g *Grid) copy() *Grid {
+	tmp := &Grid{g.Puzzle, make([]int, len(g.Cells)), make([]uint64, len(g.Cands))}
+	copy(tmp.Cells, g.Cells)
+	copy(tmp.Cands, g.Cands)
+	return tmp
+}
+
+// Descendants picks the empty cell with the fewest candidates and returns a grid for each of them
+// Every grid has its singles filled in and grids that lead to a contradiction are left out
+func (g *Grid) Descendants() []src.SearchF {
+	best := -1
+	for i, val := range g.Cells {
+		if val == 0 && (best < 0 || bits.OnesCount64(g.Cands[i]) < bits.OnesCount64(g.Cands[best])) {
+			best = i
+		}
+	}
+	tmp := make([]src.SearchF, 0)
+	if best < 0 {
+		return tmp
+	}
+	for cands := g.Cands[best]; cands != 0; cands &= cands - 1 {
+		child := g.copy()
+		if child.assign(best, bits.TrailingZeros64(cands)+1) && child.propagate() {
+			tmp = append(tmp, child)
+		}
+	}
+	return tmp
+}
+
+// Done is true when all the cells are filled
+func (g *Grid) Done() bool {
+	for _, val := range g.Cells {
+		if val == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Cost is not used
+func (g *Grid) Cost() float64 { return 0.0 }
+
+// Away is not used
+func (g *Grid) Away() float64 { return 0.0 }
+
+// Key is the values of all the cells with one byte per cell
+func (g *Grid) Key() string {
+	tmp := make([]byte, len(g.Cells))
+	for i, val := range g.Cells {
+		tmp[i] = byte(val)
+	}
+	return string(tmp)
+}
+
+// Valid checks from scratch that every unit holds every value exactly once
+func (g *Grid) Valid() bool {
+	for _, unit := range g.units {
+		var seen uint64
+		for _, c := range unit {
+			if g.Cells[c] < 1 || g.Cells[c] > g.Size {
+				return false
+			}
+			seen |= 1 << uint(g.Cells[c]-1)
+		}
+		if seen != g.all() {
+			return false
+		}
+	}
+	return true
+}
+
+// symbol returns how a value is written, 1 to 9 and then A, B, ... and . for an empty cell
+func symbol(val int) string {
+	switch {
+	case val == 0:
+		return "."
+	case val < 10:
+		return strconv.Itoa(val)
+	}
+	return string(rune('A' + val - 10))
+}
+
+// String draws the grid with lines between the boxes
+func (g *Grid) String() string {
+	sep := strings.Repeat("+"+strings.Repeat("-", 2*g.Box+1), g.Box) + "+\n"
+	tmp := ""
+	for r := 0; r < g.Size; r++ {
+		if r%g.Box == 0 {
+			tmp += sep
+		}
+		for c := 0; c < g.Size; c++ {
+			if c%g.Box == 0 {
+				tmp += "| "
+			}
+			tmp += symbol(g.Cells[r*g.Size+c]) + " "
+		}
+		tmp += "|\n"
+	}
+	return tmp + sep
+}
+
+// Parse reads a puzzle and returns the box size and the cells
+// Lines starting with # are skipped
+// If the values are separated by spaces every field is a cell, otherwise every character is a cell
+// A cell is written as a number or as 1 to 9 and A, B, ... for 10 and up, with 0, . or _ for an empty cell
+// Characters like | + and - used to draw the boxes are ignored when every character is a cell
+func Parse(r io.Reader) (int, []int, error) {
+	var lines []string
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		line := strings.TrimSpace(scan.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	if err := scan.Err(); err != nil {
+		return 0, nil, err
+	}
+	// First try every field as a cell
+	if fields := strings.Fields(strings.Join(lines, " ")); boxSize(len(fields)) > 1 {
+		cells := make([]int, 0, len(fields))
+		for _, val := range fields {
+			num, err := parseCell(val)
+			if err != nil {
+				cells = nil
+				break
+			}
+			cells = append(cells, num)
+		}
+		if cells != nil {
+			return boxSize(len(cells)), cells, nil
+		}
+	}
+	var cells []int
+	for _, ch := range strings.Join(lines, "") {
+		if strings.ContainsRune("|+- ", ch) {
+			continue
+		}
+		num, err := parseCell(string(ch))
+		if err != nil {
+			return 0, nil, err
+		}
+		cells = append(cells, num)
+	}
+	box := boxSize(len(cells))
+	if box == 0 {
+		return 0, nil, fmt.Errorf("%d cells do not make up a Sudoku grid", len(cells))
+	}
+	return box, cells, nil
+}
+
+// parseCell returns the value of a cell written as a number or a symbol (see symbol)
+func parseCell(text string) (int, error) {
+	if num, err := strconv.Atoi(text); err == nil && num >= 0 {
+		return num, nil
+	}
+	if len(text) == 1 {
+		switch ch := strings.ToUpper(text)[0]; {
+		case ch == '.' || ch == '_':
+			return 0, nil
+		case ch >= 'A' && ch <= 'Z':
+			return int(ch-'A') + 10, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid cell %q", text)
+}
+
+// boxSize returns the box size of a grid with cnt cells or 0 if there is none
+func boxSize(cnt int) int {
+	box := int(math.Sqrt(math.Sqrt(float64(cnt))) + 0.5)
+	if box*box*box*box != cnt {
+		return 0
+	}
+	return box
+}
@@ -0,0 +1,78 @@
+// heuristic.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Heuristics for the sliding tile puzzles
+package tiles
+
+// A simple integer abs function
+func iabs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// None always returns 0 which turns BestCostAwaySearch into BestCostSearch
+func None(game *Game, tiles []byte) int {
+	return 0
+}
+
+// Manhattan returns the sum over all the tiles of the rows and columns they are away from their goal position
+func Manhattan(game *Game, tiles []byte) int {
+	tot := 0
+	for i, val := range tiles {
+		if val == 0 {
+			continue
+		}
+		g := game.goalPos[val]
+		tot += iabs(i/game.Size-g/game.Size) + iabs(i%game.Size-g%game.Size)
+	}
+	return tot
+}
+
+// longestIncreasing returns the length of the longest increasing subsequence of vals
+func longestIncreasing(vals []int) int {
+	// best[i] is the length of the longest increasing subsequence ending at i
+	best := make([]int, len(vals))
+	most := 0
+	for i := range vals {
+		best[i] = 1
+		for j := 0; j < i; j++ {
+			if vals[j] < vals[i] && best[j]+1 > best[i] {
+				best[i] = best[j] + 1
+			}
+		}
+		if best[i] > most {
+			most = best[i]
+		}
+	}
+	return most
+}
+
+// LinearConflict adds to Manhattan for tiles that are in their goal row (or column) but in the wrong order
+// All but the tiles of the longest correctly ordered subsequence have to leave the line and come back,
+// which costs 2 more moves each than Manhattan counts
+func LinearConflict(game *Game, tiles []byte) int {
+	n := game.Size
+	tot := Manhattan(game, tiles)
+	line := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		// Tiles in row i whose goal is also row i, by goal column
+		line = line[:0]
+		for c := 0; c < n; c++ {
+			if val := tiles[i*n+c]; val != 0 && game.goalPos[val]/n == i {
+				line = append(line, game.goalPos[val]%n)
+			}
+		}
+		tot += 2 * (len(line) - longestIncreasing(line))
+		// Tiles in column i whose goal is also column i, by goal row
+		line = line[:0]
+		for r := 0; r < n; r++ {
+			if val := tiles[r*n+i]; val != 0 && game.goalPos[val]%n == i {
+				line = append(line, game.goalPos[val]/n)
+			}
+		}
+		tot += 2 * (len(line) - longestIncreasing(line))
+	}
+	return tot
+}
@@ -0,0 +1,60 @@
+// instances.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Puzzles with known optimal solution lengths, shared by the tests of the searches and the examples comparing them
+package tiles
+
+import (
+	"math"
+)
+
+// Instance is a puzzle with the length of its optimal solution
+// Tiles are the tiles row by row with 0 for the blank and Goal the goal order, empty for the usual goal
+type Instance struct {
+	Tiles string
+	Goal  string
+	Moves int
+}
+
+// Instances are the puzzles with known optimal solutions, the 8-puzzles first and then the 15-puzzles
+var Instances = []Instance{
+	{"1 2 3 4 5 6 7 8 0", "", 0},
+	{"1 2 3 4 5 6 0 7 8", "", 2},
+	{"0 1 3 4 2 5 7 8 6", "", 4},
+	{"4 1 3 7 2 6 0 5 8", "", 6},
+	{"8 1 3 4 0 2 7 6 5", "", 14},
+	{"7 2 4 5 0 6 8 3 1", "", 20},
+	{"8 7 6 5 4 3 2 1 0", "", 30},
+	{"8 6 7 2 5 4 3 0 1", "", 31},
+	{"6 4 7 8 5 0 3 2 1", "", 31},
+	{"1 2 3 4 5 6 7 8 9 10 0 11 13 14 15 12", "", 2},
+	{"2 6 4 8 5 1 7 3 0 9 14 11 13 15 10 12", "", 20},
+	{"2 4 3 11 1 7 12 6 5 10 0 8 9 13 14 15", "", 24},
+	{"2 3 7 4 1 13 9 8 14 10 5 12 6 11 15 0", "", 28},
+	{"4 3 8 6 1 2 11 10 5 12 0 15 9 13 14 7", "", 36},
+	{"3 14 9 11 5 4 8 2 13 12 6 7 10 1 15 0", "0 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15", 46},
+}
+
+// Start returns the game of the instance with the heuristic and its start state
+func (inst Instance) Start(h Heuristic) (*Game, *State, error) {
+	tls, err := Parse(inst.Tiles)
+	if err != nil {
+		return nil, nil, err
+	}
+	n := int(math.Sqrt(float64(len(tls))))
+	goal := DefaultGoal(n)
+	if inst.Goal != "" {
+		if goal, err = Parse(inst.Goal); err != nil {
+			return nil, nil, err
+		}
+	}
+	game, err := NewGame(n, goal, h)
+	if err != nil {
+		return nil, nil, err
+	}
+	start, err := game.Start(tls)
+	if err != nil {
+		return nil, nil, err
+	}
+	return game, start, nil
+}
@@ -0,0 +1,283 @@
+// pdb.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Additive pattern databases for the sliding tile puzzles
+// The tiles are split into disjoint patterns and for every pattern a table holds, for every placement of its tiles,
+// the least number of moves of those tiles needed to get them to their goal positions (the other tiles are
+// indistinguishable and their moves are not counted)
+// Because no move is counted in more than one pattern the values of the patterns can be added up
+package tiles
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// PDB is a set of disjoint pattern databases for the same puzzle and goal
+// Patterns has the tiles in each pattern
+// Tables has for each pattern the moves needed, indexed by the rank of the positions of its tiles
+type PDB struct {
+	Size     int
+	Goal     []byte
+	Patterns [][]byte
+	Tables   [][]byte
+}
+
+// perm returns the number of ways m items can be placed on c cells (c!/(c-m)!)
+func perm(c, m int) int {
+	tot := 1
+	for i := 0; i < m; i++ {
+		tot *= c - i
+	}
+	return tot
+}
+
+// rank returns a unique number in [0,perm(cells,len(pos))) for the positions of the items
+// Every position is counted among the cells not used by the items before it
+func rank(pos []int, cells int) int {
+	var used uint32
+	tot := 0
+	for i, p := range pos {
+		free := p - bits.OnesCount32(used&(1<<uint(p)-1))
+		tot = tot*(cells-i) + free
+		used |= 1 << uint(p)
+	}
+	return tot
+}
+
+// The states searched while building a table are packed with 4 bits per item position, which allows puzzles up to 4X4
+const maxCells = 16
+
+// pack puts the positions into a single value
+func pack(pos []int) uint64 {
+	var tmp uint64
+	for i, p := range pos {
+		tmp |= uint64(p) << uint(4*i)
+	}
+	return tmp
+}
+
+// unpack gets the positions out of a packed value
+func unpack(val uint64, pos []int) {
+	for i := range pos {
+		pos[i] = int(val>>uint(4*i)) & 15
+	}
+}
+
+// bitset is used to mark the states already reached
+type bitset []uint64
+
+func (bs bitset) get(i int) bool { return bs[i/64]&(1<<uint(i%64)) != 0 }
+func (bs bitset) set(i int)      { bs[i/64] |= 1 << uint(i%64) }
+
+// buildTable does a breadth first search backwards from the goal over the positions of the pattern tiles and the blank
+// Moving the blank onto a cell of another tile costs nothing, moving a pattern tile costs 1
+// so the search is done one cost at a time, first reaching everything that costs nothing more
+// The table keeps the least cost over all the positions of the blank
+func buildTable(game *Game, pattern []byte) []byte {
+	n, cells := game.Size, game.Size*game.Size
+	k := len(pattern)
+	visited := make(bitset, (perm(cells, k+1)+63)/64)
+	table := make([]byte, perm(cells, k))
+	for i := range table {
+		table[i] = 255
+	}
+	// The items are the pattern tiles followed by the blank
+	pos := make([]int, k+1)
+	for i, val := range pattern {
+		pos[i] = game.goalPos[val]
+	}
+	pos[k] = game.goalPos[0]
+	visited.set(rank(pos, cells))
+	cur := []uint64{pack(pos)}
+	var next []uint64
+	var occ [maxCells]int
+	for d := 0; len(cur) > 0; d++ {
+		for i := 0; i < len(cur); i++ {
+			unpack(cur[i], pos)
+			if idx := rank(pos[:k], cells); table[idx] == 255 {
+				table[idx] = byte(d)
+			}
+			for j := range occ[:cells] {
+				occ[j] = -1
+			}
+			for j, p := range pos[:k] {
+				occ[p] = j
+			}
+			blank := pos[k]
+			r, c := blank/n, blank%n
+			for _, dir := range directions {
+				r2, c2 := r+dir.dr, c+dir.dc
+				if r2 < 0 || r2 >= n || c2 < 0 || c2 >= n {
+					continue
+				}
+				cell := r2*n + c2
+				pos[k] = cell
+				if j := occ[cell]; j >= 0 {
+					// A pattern tile slides into the blank, this costs a move so it goes into the next layer
+					pos[j] = blank
+					next = append(next, pack(pos))
+					pos[j] = cell
+				} else if idx := rank(pos, cells); !visited.get(idx) {
+					visited.set(idx)
+					cur = append(cur, pack(pos))
+				}
+				pos[k] = blank
+			}
+		}
+		// The next layer only gets the states not already reached more cheaply
+		cur = cur[:0]
+		for _, val := range next {
+			unpack(val, pos)
+			if idx := rank(pos, cells); !visited.get(idx) {
+				visited.set(idx)
+				cur = append(cur, val)
+			}
+		}
+		next = next[:0]
+	}
+	return table
+}
+
+// checkPatterns makes sure that the patterns are for a puzzle of size n up to 4X4, do not share tiles and do not
+// include the blank
+func checkPatterns(n int, patterns [][]byte) error {
+	if n < 2 || n*n > maxCells {
+		return fmt.Errorf("pattern databases can only be for puzzles from 2X2 up to 4X4")
+	}
+	seen := make([]bool, n*n)
+	for _, pat := range patterns {
+		for _, val := range pat {
+			if val == 0 || int(val) >= len(seen) || seen[val] {
+				return fmt.Errorf("tile %d is invalid or in more than one pattern", val)
+			}
+			seen[val] = true
+		}
+	}
+	return nil
+}
+
+// BuildPDB builds the tables for the disjoint patterns given for the game's goal
+// The patterns must not share tiles and may not include the blank
+func BuildPDB(game *Game, patterns [][]byte) (*PDB, error) {
+	if err := checkPatterns(game.Size, patterns); err != nil {
+		return nil, err
+	}
+	db := &PDB{Size: game.Size, Goal: game.Goal, Patterns: patterns}
+	for _, pat := range patterns {
+		db.Tables = append(db.Tables, buildTable(game, pat))
+	}
+	return db, nil
+}
+
+// Heuristic adds up the values of all the patterns for the positions of their tiles
+// It can be used as the Heuristic of a Game with the same size and goal
+func (db *PDB) Heuristic(game *Game, tiles []byte) int {
+	var where [maxCells]int
+	for i, val := range tiles {
+		where[val] = i
+	}
+	cells := db.Size * db.Size
+	pos := make([]int, 0, cells)
+	tot := 0
+	for i, pat := range db.Patterns {
+		pos = pos[:0]
+		for _, val := range pat {
+			pos = append(pos, where[val])
+		}
+		tot += int(db.Tables[i][rank(pos, cells)])
+	}
+	return tot
+}
+
+// The file starts with this so that other files are not read as pattern databases
+var pdbMagic = []byte("TPDB")
+
+// Save writes the pattern database to w
+// After the magic comes the size, the goal, the number of patterns and for every pattern its tiles and table
+// Everything after the magic is compressed with gzip
+func (db *PDB) Save(w io.Writer) error {
+	if _, err := w.Write(pdbMagic); err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(w)
+	buf := bufio.NewWriter(zw)
+	buf.WriteByte(byte(db.Size))
+	buf.Write(db.Goal)
+	buf.WriteByte(byte(len(db.Patterns)))
+	for i, pat := range db.Patterns {
+		buf.WriteByte(byte(len(pat)))
+		buf.Write(pat)
+		binary.Write(buf, binary.LittleEndian, uint32(len(db.Tables[i])))
+		buf.Write(db.Tables[i])
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// LoadPDB reads a pattern database written by Save
+// The size, goal and patterns are checked the same way as for BuildPDB so that Heuristic can not index out of range
+func LoadPDB(r io.Reader) (*PDB, error) {
+	magic := make([]byte, len(pdbMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != string(pdbMagic) {
+		return nil, errors.New("not a pattern database")
+	}
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	buf := bufio.NewReader(zr)
+	size, err := buf.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if size < 2 || int(size)*int(size) > maxCells {
+		return nil, fmt.Errorf("pattern database is for a puzzle of size %d, only 2 to 4 are supported", size)
+	}
+	db := &PDB{Size: int(size), Goal: make([]byte, int(size)*int(size))}
+	if _, err := io.ReadFull(buf, db.Goal); err != nil {
+		return nil, err
+	}
+	if err := check(db.Size, db.Goal); err != nil {
+		return nil, fmt.Errorf("pattern database has an invalid goal: %v", err)
+	}
+	cnt, err := buf.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < int(cnt); i++ {
+		k, err := buf.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		pat := make([]byte, k)
+		if _, err := io.ReadFull(buf, pat); err != nil {
+			return nil, err
+		}
+		var length uint32
+		if err := binary.Read(buf, binary.LittleEndian, &length); err != nil {
+			return nil, err
+		}
+		if int(length) != perm(db.Size*db.Size, int(k)) {
+			return nil, errors.New("pattern database table has the wrong length")
+		}
+		table := make([]byte, length)
+		if _, err := io.ReadFull(buf, table); err != nil {
+			return nil, err
+		}
+		db.Patterns = append(db.Patterns, pat)
+		db.Tables = append(db.Tables, table)
+	}
+	if err := checkPatterns(db.Size, db.Patterns); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
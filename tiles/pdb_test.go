@@ -0,0 +1,106 @@
+// pdb_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks the pattern databases on the 8-puzzle and that only valid files are loaded
+package tiles_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/search"
+	"github.com/hduplooy/gosearch-test/tiles"
+)
+
+// buildPDB builds a 4-4 pattern database for the usual goal of the 8-puzzle
+func buildPDB(t *testing.T) (*tiles.Game, *tiles.PDB) {
+	t.Helper()
+	game, err := tiles.NewGame(3, tiles.DefaultGoal(3), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := tiles.BuildPDB(game, [][]byte{{1, 2, 3, 4}, {5, 6, 7, 8}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.H = db.Heuristic
+	return game, db
+}
+
+func TestPDBOptimal(t *testing.T) {
+	game, db := buildPDB(t)
+	for _, inst := range tiles.Instances {
+		tls, _ := tiles.Parse(inst.Tiles)
+		if len(tls) != 9 || inst.Goal != "" {
+			continue
+		}
+		if h, m := db.Heuristic(game, tls), tiles.Manhattan(game, tls); h < m || h > inst.Moves {
+			t.Errorf("%s: pattern database gives %d, Manhattan %d and the optimal length is %d", inst.Tiles, h, m, inst.Moves)
+		}
+		start, err := game.Start(tls)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, _ := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+		if res.Goal == nil || res.Cost != float64(inst.Moves) {
+			t.Errorf("%s: solved in %v moves, expected %d", inst.Tiles, res.Cost, inst.Moves)
+		}
+	}
+}
+
+func TestPDBSaveLoad(t *testing.T) {
+	game, db := buildPDB(t)
+	var buf bytes.Buffer
+	if err := db.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	db2, err := tiles.LoadPDB(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db2.Size != db.Size || string(db2.Goal) != string(db.Goal) || len(db2.Tables) != len(db.Tables) {
+		t.Fatalf("loaded size %d goal %v with %d tables", db2.Size, db2.Goal, len(db2.Tables))
+	}
+	for i := range db.Tables {
+		if string(db2.Patterns[i]) != string(db.Patterns[i]) || !bytes.Equal(db2.Tables[i], db.Tables[i]) {
+			t.Errorf("pattern %d is not the same after loading", i)
+		}
+	}
+	tls, _ := tiles.Parse("8 6 7 2 5 4 3 0 1")
+	if a, b := db.Heuristic(game, tls), db2.Heuristic(game, tls); a != b {
+		t.Errorf("heuristic %d before saving and %d after loading", a, b)
+	}
+}
+
+// pdbFile returns a pattern database file with the bytes given after the magic (compressed like Save does)
+func pdbFile(body []byte) *bytes.Buffer {
+	var buf bytes.Buffer
+	buf.WriteString("TPDB")
+	zw := gzip.NewWriter(&buf)
+	zw.Write(body)
+	zw.Close()
+	return &buf
+}
+
+func TestLoadPDBInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		file *bytes.Buffer
+	}{
+		{"no magic", bytes.NewBufferString("PDBT")},
+		{"size 1", pdbFile([]byte{1, 0, 0})},
+		{"size 5", pdbFile(append([]byte{5}, make([]byte, 25)...))},
+		{"size 200", pdbFile([]byte{200})},
+		{"invalid goal", pdbFile([]byte{2, 0, 1, 1, 3, 0})},
+		{"blank in pattern", pdbFile([]byte{2, 1, 2, 3, 0, 1, 1, 0, 4, 0, 0, 0, 0, 0, 0, 0})},
+		{"table too short", pdbFile([]byte{2, 1, 2, 3, 0, 1, 1, 1, 3, 0, 0, 0, 0, 0})},
+		{"cut short", pdbFile([]byte{3, 1, 2, 3})},
+	}
+	for _, test := range tests {
+		if _, err := tiles.LoadPDB(test.file); err == nil {
+			t.Errorf("%s: loaded without an error", test.name)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+// tiles.go
+// Author: Hannes du Plooy
+// Revision Date: 18 Oct 2026
+// Package tiles implements the sliding tile puzzles (8-puzzle, 15-puzzle, ...) as states for hduplooy/gosearch
+// An NXN frame holds N*N-1 numbered tiles and one blank, a tile next to the blank can slide into it
+// The tiles must be brought into the goal order with the least moves
+package tiles
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	src "github.com/hduplooy/gosearch"
+)
+
+// Heuristic estimates the number of moves still needed to reach the goal, it may never overestimate
+type Heuristic func(game *Game, tiles []byte) int
+
+// Game holds everything shared by all the states of a puzzle
+// Size is the number of rows (and columns)
+// Goal is the goal order of the tiles (0 is the blank)
+// H is the heuristic used as Away
+// goalPos has for every tile its position in the goal
+type Game struct {
+	Size    int
+	Goal    []byte
+	H       Heuristic
+	goalPos []int
+}
+
+// DefaultGoal returns the usual goal for a puzzle of size n with the tiles in order and the blank last
+func DefaultGoal(n int) []byte {
+	tmp := make([]byte, n*n)
+	for i := 0; i < n*n-1; i++ {
+		tmp[i] = byte(i + 1)
+	}
+	return tmp
+}
+
+// NewGame sets up a puzzle of size n with the goal and heuristic given
+func NewGame(n int, goal []byte, h Heuristic) (*Game, error) {
+	if err := check(n, goal); err != nil {
+		return nil, fmt.Errorf("invalid goal: %v", err)
+	}
+	game := &Game{Size: n, Goal: goal, H: h, goalPos: make([]int, n*n)}
+	for i, val := range goal {
+		game.goalPos[val] = i
+	}
+	return game, nil
+}
+
+// check makes sure that tiles holds every tile of a puzzle of size n exactly once
+func check(n int, tiles []byte) error {
+	if len(tiles) != n*n {
+		return fmt.Errorf("expected %d tiles but got %d", n*n, len(tiles))
+	}
+	seen := make([]bool, n*n)
+	for _, val := range tiles {
+		if int(val) >= n*n || seen[val] {
+			return fmt.Errorf("tile %d is invalid or used more than once", val)
+		}
+		seen[val] = true
+	}
+	return nil
+}
+
+// Parse reads the tiles row by row separated by spaces, commas or new lines with 0 (or _) for the blank
+// The size of the puzzle follows from the number of tiles
+func Parse(text string) ([]byte, error) {
+	fields := strings.FieldsFunc(text, func(ch rune) bool {
+		return ch == ' ' || ch == ',' || ch == '\t' || ch == '\n' || ch == '\r'
+	})
+	n := int(math.Sqrt(float64(len(fields))))
+	if n < 2 || n*n != len(fields) || n*n > 256 {
+		return nil, fmt.Errorf("%d tiles do not make up a square puzzle", len(fields))
+	}
+	tmp := make([]byte, len(fields))
+	for i, val := range fields {
+		if val == "_" {
+			val = "0"
+		}
+		num, err := strconv.Atoi(val)
+		if err != nil || num < 0 || num >= len(fields) {
+			return nil, fmt.Errorf("invalid tile %q", val)
+		}
+		tmp[i] = byte(num)
+	}
+	if err := check(n, tmp); err != nil {
+		return nil, err
+	}
+	return tmp, nil
+}
+
+// parity returns a value that no move can change
+// For an odd size it is the parity of the number of inversions (pairs of tiles in the wrong order, ignoring the blank)
+// For an even size a vertical move changes the inversions by an odd number and the row of the blank by one,
+// so the parity of their sum is used
+func parity(n int, tiles []byte) int {
+	inv := 0
+	for i := range tiles {
+		for j := i + 1; j < len(tiles); j++ {
+			if tiles[i] != 0 && tiles[j] != 0 && tiles[i] > tiles[j] {
+				inv++
+			}
+		}
+	}
+	if n%2 == 0 {
+		for i, val := range tiles {
+			if val == 0 {
+				inv += i / n
+			}
+		}
+	}
+	return inv % 2
+}
+
+// Solvable is true if the goal can be reached from tiles
+func (game *Game) Solvable(tiles []byte) bool {
+	return parity(game.Size, tiles) == parity(game.Size, game.Goal)
+}
+
+// ErrUnsolvable is returned by Start for puzzles whose goal can't be reached
+var ErrUnsolvable = errors.New("the puzzle can not be solved")
+
+// Start returns the starting state of the puzzle after checking that it can be solved
+func (game *Game) Start(tiles []byte) (*State, error) {
+	if err := check(game.Size, tiles); err != nil {
+		return nil, err
+	}
+	if !game.Solvable(tiles) {
+		return nil, ErrUnsolvable
+	}
+	st := &State{Game: game, Tiles: tiles}
+	for i, val := range tiles {
+		if val == 0 {
+			st.Blank = i
+		}
+	}
+	st.h = game.H(game, tiles)
+	return st, nil
+}
+
+// State is the state of the puzzle
+// Tiles is the tile on every position (row*Size+column), 0 is the blank
+// Blank is the position of the blank
+// Moves is the number of moves made so far
+// Last is the direction the blank moved to get here (U, D, L or R) and 0 for the start
+// h is the value of the heuristic
+type State struct {
+	*Game
+	Tiles []byte
+	Blank int
+	Moves int
+	Last  byte
+	h     int
+}
+
+// The directions the blank can move in with the change in row and column and the opposite direction
+var directions = []struct {
+	name     byte
+	dr, dc   int
+	opposite byte
+}{{'U', -1, 0, 'D'}, {'D', 1, 0, 'U'}, {'L', 0, -1, 'R'}, {'R', 0, 1, 'L'}}
+
+// Descendants returns the states after moving the blank in every direction possible
+// Moving it back to where it just came from is left out
+func (st *State) Descendants() []src.SearchF {
+	tmp := make([]src.SearchF, 0, 4)
+	r, c := st.Blank/st.Size, st.Blank%st.Size
+	for _, dir := range directions {
+		if st.Last == dir.opposite {
+			continue
+		}
+		r2, c2 := r+dir.dr, c+dir.dc
+		if r2 < 0 || r2 >= st.Size || c2 < 0 || c2 >= st.Size {
+			continue
+		}
+		pos := r2*st.Size + c2
+		tiles := make([]byte, len(st.Tiles))
+		copy(tiles, st.Tiles)
+		tiles[st.Blank], tiles[pos] = tiles[pos], 0
+		tmp = append(tmp, &State{st.Game, tiles, pos, st.Moves + 1, dir.name, st.H(st.Game, tiles)})
+	}
+	return tmp
+}
+
+// Done is true if the tiles are in the goal order
+func (st *State) Done() bool {
+	return string(st.Tiles) == string(st.Goal)
+}
+
+// Cost is the number of moves made so far
+func (st *State) Cost() float64 { return float64(st.Moves) }
+
+// Away is the heuristic estimate of the moves still needed
+func (st *State) Away() float64 { return float64(st.h) }
+
+// Key is the tiles as a string, every tile is one byte
+func (st *State) Key() string {
+	return string(st.Tiles)
+}
+
+// String draws the puzzle
+func (st *State) String() string {
+	width := len(strconv.Itoa(st.Size*st.Size - 1))
+	line := strings.Repeat("+"+strings.Repeat("-", width), st.Size) + "+\n"
+	tmp := line
+	for r := 0; r < st.Size; r++ {
+		tmp += "|"
+		for c := 0; c < st.Size; c++ {
+			if val := st.Tiles[r*st.Size+c]; val > 0 {
+				tmp += fmt.Sprintf("%*d|", width, val)
+			} else {
+				tmp += strings.Repeat(" ", width) + "|"
+			}
+		}
+		tmp += "\n" + line
+	}
+	return tmp
+}
+
+// Path returns the moves of the blank from the start to the goal given the history and answer of a search
+// The history is in the order gosearch returns it (the state just before the answer first)
+func Path(hist []src.SearchF, ans src.SearchF) string {
+	tmp := make([]byte, 0, len(hist)+1)
+	for i := len(hist) - 1; i >= 0; i-- {
+		if last := hist[i].(*State).Last; last != 0 {
+			tmp = append(tmp, last)
+		}
+	}
+	if last := ans.(*State).Last; last != 0 {
+		tmp = append(tmp, last)
+	}
+	return string(tmp)
+}
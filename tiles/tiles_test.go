@@ -0,0 +1,111 @@
+// tiles_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Solves sliding tile puzzles with known optimal solution lengths and checks parsing and solvability
+package tiles_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/search"
+	"github.com/hduplooy/gosearch-test/tiles"
+)
+
+// size returns the size of the puzzle with the tiles given
+func size(tls []byte) int {
+	n := 0
+	for n*n < len(tls) {
+		n++
+	}
+	return n
+}
+
+func TestOptimalLengths(t *testing.T) {
+	heuristics := []struct {
+		name string
+		h    tiles.Heuristic
+	}{
+		{"manhattan", tiles.Manhattan},
+		{"linear", tiles.LinearConflict},
+	}
+	for _, hr := range heuristics {
+		for _, inst := range tiles.Instances {
+			_, start, err := inst.Start(hr.h)
+			if err != nil {
+				t.Fatalf("%s: %v", inst.Tiles, err)
+			}
+			res, _ := search.BestCostAwayContext(context.Background(), start, search.Limits{})
+			if res.Goal == nil {
+				t.Errorf("%s with %s: no solution found", inst.Tiles, hr.name)
+				continue
+			}
+			if path := tiles.Path(res.History(), res.Goal); len(path) != inst.Moves {
+				t.Errorf("%s with %s: solved in %d moves, expected %d", inst.Tiles, hr.name, len(path), inst.Moves)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		ok   bool
+	}{
+		{"1 2 3 4 5 6 7 8 0", true},
+		{"1,2,3\n4,5,6\n7,8,_", true},
+		{"1 2 3 0", true},
+		{"", false},
+		{"0", false},
+		{"1 2 3 4 5 6 7 0", false},
+		{"1 2 3 4 5 6 7 8 0 9", false},
+		{"1 2 3 4 5 6 7 8 8", false},
+		{"1 1 3 4 5 6 7 8 0", false},
+		{"1 2 3 4 5 6 7 8 9", false},
+		{"1 2 3 4 5 6 7 8 x", false},
+		{"1 2 3 4 5 6 7 8 -1", false},
+	}
+	for _, test := range tests {
+		tls, err := tiles.Parse(test.text)
+		if test.ok && err != nil {
+			t.Errorf("Parse(%q) failed: %v", test.text, err)
+		}
+		if !test.ok && err == nil {
+			t.Errorf("Parse(%q) gave %v instead of an error", test.text, tls)
+		}
+	}
+}
+
+func TestSolvable(t *testing.T) {
+	tests := []struct {
+		text     string
+		solvable bool
+	}{
+		{"1 2 3 4 5 6 7 8 0", true},
+		{"8 6 7 2 5 4 3 0 1", true},
+		{"2 1 3 4 5 6 7 8 0", false},
+		{"1 2 3 4 5 6 8 7 0", false},
+		{"1 2 3 0", true},
+		{"0 1 3 2", true},
+		{"2 1 3 0", false},
+		{"1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 0", true},
+		{"1 2 3 4 5 6 7 8 9 10 11 12 13 15 14 0", false},
+		{"1 2 3 4 5 6 7 8 9 10 11 0 13 14 15 12", true},
+		{"1 2 3 4 5 6 7 8 9 10 11 12 0 13 14 15", true},
+		{"1 2 3 4 5 6 7 8 9 10 11 0 13 15 14 12", false},
+	}
+	for _, test := range tests {
+		tls, err := tiles.Parse(test.text)
+		if err != nil {
+			t.Fatalf("%s: %v", test.text, err)
+		}
+		n := size(tls)
+		game, _ := tiles.NewGame(n, tiles.DefaultGoal(n), tiles.Manhattan)
+		if got := game.Solvable(tls); got != test.solvable {
+			t.Errorf("Solvable(%s) = %v, expected %v", test.text, got, test.solvable)
+		}
+		if _, err := game.Start(tls); (err == tiles.ErrUnsolvable) == test.solvable {
+			t.Errorf("Start(%s) gave error %v", test.text, err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// adapt.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Adapters from the SearchF states of hduplooy/gosearch, so that the states of the other packages can be searched
+// with a Search without writing an Expand for them (it still gets the descendants from Descendants)
+package typed
+
+import (
+	src "github.com/hduplooy/gosearch"
+)
+
+// Adapt returns the search over the SearchF type S with its string keys
+// All the descendants of a state of type S must be of type S as well, the goal found is then an S without a type
+// assertion by the caller
+func Adapt[S src.SearchF]() *Search[S, string] {
+	return &Search[S, string]{
+		Expand: func(st S, yield func(S) bool) {
+			for _, desc := range st.Descendants() {
+				if !yield(desc.(S)) {
+					return
+				}
+			}
+		},
+		Done: func(st S) bool { return st.Done() },
+		Cost: func(st S) float64 { return st.Cost() },
+		Away: func(st S) float64 { return st.Away() },
+		Key:  func(st S) string { return st.Key() },
+	}
+}
+
+// AdaptAny returns the search over SearchF states of any type, for state spaces with more than one state type
+func AdaptAny() *Search[src.SearchF, string] {
+	return Adapt[src.SearchF]()
+}
@@ -0,0 +1,70 @@
+// problems.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Typed searches written for the N queens BitBoard and drives on a road network, used by genericsearch.go and the
+// tests to compare them with the same problems searched through SearchF and with Adapt
+package typed
+
+import (
+	"github.com/hduplooy/gosearch-test/queens"
+	"github.com/hduplooy/gosearch-test/roads"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// QueensKey is the key of a BitBoard, the rank is needed as the files of the ranks not placed are 0
+type QueensKey struct {
+	Rank  int
+	Files [queens.MaxSize]int8
+}
+
+// Queens is the typed search over BitBoard that uses Expand (which does not allocate) and QueensKey
+var Queens = &Search[queens.BitBoard, QueensKey]{
+	Expand: func(brd queens.BitBoard, yield func(queens.BitBoard) bool) { brd.Expand(yield) },
+	Done:   func(brd queens.BitBoard) bool { return brd.Done() },
+	Key:    func(brd queens.BitBoard) QueensKey { return QueensKey{brd.Rank, brd.Files} },
+}
+
+// Drive is a typed state of a drive on a network, the city reached and the distance travelled
+type Drive struct {
+	City *roads.City
+	Dist float64
+}
+
+// Drives returns the typed search for drives to the destination, the key is the key of the city
+func Drives(dest *roads.City) *Search[Drive, int] {
+	return &Search[Drive, int]{
+		Expand: func(dr Drive, yield func(Drive) bool) {
+			for i, val := range dr.City.Neighbours {
+				if !yield(Drive{val, dr.Dist + dr.City.Distances[i]}) {
+					return
+				}
+			}
+		},
+		Done: func(dr Drive) bool { return dr.City == dest },
+		Cost: func(dr Drive) float64 { return dr.Dist },
+		Away: func(dr Drive) float64 { return dr.City.Distance(dest) },
+		Key:  func(dr Drive) int { return dr.City.Key },
+	}
+}
+
+// Outcome is what a search found that is compared between the ways of searching a problem
+type Outcome struct {
+	Found    bool
+	Cost     float64
+	Expanded int
+}
+
+// Outcome returns the outcome of the typed search
+func (res *Result[S]) Outcome() Outcome {
+	return Outcome{res.Found, res.Cost, res.Expanded}
+}
+
+// OutcomeOf returns the outcome of a search of the search package
+func OutcomeOf(res *search.Result) Outcome {
+	return Outcome{res.Goal != nil, res.Cost, res.Expanded}
+}
+
+// Same is true if the outcomes are the same (the costs may differ by rounding)
+func (out Outcome) Same(other Outcome) bool {
+	return out.Found == other.Found && out.Expanded == other.Expanded && out.Cost-other.Cost <= 1e-6 && other.Cost-out.Cost <= 1e-6
+}
@@ -0,0 +1,301 @@
+// typed.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Package typed has the searches of the search package for states of any type with keys of any comparable type
+// The states of hduplooy/gosearch are SearchF interfaces, so every descendant is boxed in an interface, Descendants
+// allocates a slice for them, the keys are strings and a goal has to be type asserted back to the state type
+// Here the descendants are passed to a function one at a time, the keys can be numbers or arrays and a goal is of
+// the state type. The nodes of a search are kept in chunks of many nodes so there is no allocation per node either
+package typed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// Search is a state space with states of type S and keys of type K
+// Expand calls yield for every descendant of the state, it must stop when yield returns false
+// Done is true for a goal state and Key returns the key of a state (states with the same key are duplicates)
+// Cost and Away are those of hduplooy/gosearch, they are only needed by the searches that use them
+type Search[S any, K comparable] struct {
+	Expand func(st S, yield func(S) bool)
+	Done   func(S) bool
+	Cost   func(S) float64
+	Away   func(S) float64
+	Key    func(S) K
+}
+
+// Result is what a search found
+// Goal is the goal state and Found is false if there was none, Path the states from the start to the goal and Cost
+// the cost of the goal (0 if there is no Cost). Time is how long the search took
+type Result[S any] struct {
+	Goal  S
+	Found bool
+	Path  []S
+	Cost  float64
+	search.Stats
+	Time time.Duration
+}
+
+// String is a summary of the statistics
+func (res *Result[S]) String() string {
+	return fmt.Sprintf("Done in %d steps (%d generated, %d duplicates, peak frontier %d, peak closed %d) in %v",
+		res.Expanded, res.Generated, res.Duplicates, res.PeakFrontier, res.PeakClosed, res.Time.Round(time.Microsecond))
+}
+
+// node is a state of the search with the index of the node it was generated from (-1 for the start)
+type node[S any, K comparable] struct {
+	state  S
+	key    K
+	parent int
+	prio   float64
+}
+
+// chunkSize is the number of nodes in a chunk of an arena
+const chunkSize = 1024
+
+// arena holds all the nodes of a search by index, in chunks so that they are never moved
+type arena[S any, K comparable] struct {
+	chunks [][]node[S, K]
+	size   int
+}
+
+// add adds the node and returns its index
+func (a *arena[S, K]) add(n node[S, K]) int {
+	if a.size%chunkSize == 0 {
+		a.chunks = append(a.chunks, make([]node[S, K], 0, chunkSize))
+	}
+	last := &a.chunks[len(a.chunks)-1]
+	*last = append(*last, n)
+	a.size++
+	return a.size - 1
+}
+
+// at returns the node with the index
+func (a *arena[S, K]) at(i int) *node[S, K] {
+	return &a.chunks[i/chunkSize][i%chunkSize]
+}
+
+// frontier holds the indexes of the nodes still to be expanded
+type frontier interface {
+	push(int)
+	pop() int
+	size() int
+}
+
+// stack is the frontier of depth first search, the last descendant is expanded first
+type stack []int
+
+func (s *stack) push(i int) { *s = append(*s, i) }
+func (s *stack) size() int  { return len(*s) }
+func (s *stack) pop() int {
+	i := (*s)[len(*s)-1]
+	*s = (*s)[:len(*s)-1]
+	return i
+}
+
+// queue is the frontier of breadth first search
+type queue struct {
+	items []int
+	first int
+}
+
+func (q *queue) push(i int) { q.items = append(q.items, i) }
+func (q *queue) size() int  { return len(q.items) - q.first }
+func (q *queue) pop() int {
+	i := q.items[q.first]
+	q.first++
+	// Move what is left to the front once the front half is used up
+	if q.first > len(q.items)/2 && q.first > 1024 {
+		q.items = append(q.items[:0], q.items[q.first:]...)
+		q.first = 0
+	}
+	return i
+}
+
+// priority is the frontier of the best first searches, the lowest prio first and then the first generated
+// (which has the lowest index)
+// It is a binary heap of its own as container/heap would box every index in an interface
+type priority[S any, K comparable] struct {
+	items []int
+	nodes *arena[S, K]
+}
+
+func (p *priority[S, K]) less(i, j int) bool {
+	a, b := p.items[i], p.items[j]
+	if pa, pb := p.nodes.at(a).prio, p.nodes.at(b).prio; pa != pb {
+		return pa < pb
+	}
+	return a < b
+}
+func (p *priority[S, K]) size() int { return len(p.items) }
+func (p *priority[S, K]) push(i int) {
+	p.items = append(p.items, i)
+	for j := len(p.items) - 1; j > 0; {
+		up := (j - 1) / 2
+		if !p.less(j, up) {
+			break
+		}
+		p.items[j], p.items[up] = p.items[up], p.items[j]
+		j = up
+	}
+}
+func (p *priority[S, K]) pop() int {
+	top := p.items[0]
+	last := len(p.items) - 1
+	p.items[0] = p.items[last]
+	p.items = p.items[:last]
+	for j := 0; ; {
+		low := j
+		if l := 2*j + 1; l < last && p.less(l, low) {
+			low = l
+		}
+		if r := 2*j + 2; r < last && p.less(r, low) {
+			low = r
+		}
+		if low == j {
+			break
+		}
+		p.items[j], p.items[low] = p.items[low], p.items[j]
+		j = low
+	}
+	return top
+}
+
+// run is the search loop shared by all the searches like that of the search package, only the frontier differs
+// prio gives the priority of a state for the best first searches (nil for the others)
+// The context is checked every search.CheckEvery expansions like there, but Memory only counts search.StateSize for
+// every state as the keys are not strings and their size is not known
+func (sp *Search[S, K]) run(ctx context.Context, start S, limits search.Limits, open frontier, nodes *arena[S, K], prio func(S) float64) (*Result[S], error) {
+	tm := time.Now()
+	res := &Result[S]{}
+	stats := &res.Stats
+	closed := make(map[K]struct{})
+	add := func(st S, key K, parent int) {
+		n := node[S, K]{state: st, key: key, parent: parent}
+		if prio != nil {
+			n.prio = prio(st)
+		}
+		open.push(nodes.add(n))
+		stats.Memory += search.StateSize
+	}
+	add(start, sp.Key(start), -1)
+	// finish fills in the rest of the result, with the goal found in the node with index goal if it is not -1
+	finish := func(goal int, reason search.Reason, err error) (*Result[S], error) {
+		stats.Frontier, stats.Closed, stats.PeakClosed = open.size(), len(closed), len(closed)
+		res.Time = time.Since(tm)
+		if goal >= 0 {
+			res.Goal, res.Found = nodes.at(goal).state, true
+			if sp.Cost != nil {
+				res.Cost = sp.Cost(res.Goal)
+			}
+			for i := goal; i >= 0; i = nodes.at(i).parent {
+				res.Path = append(res.Path, nodes.at(i).state)
+			}
+			// The path was collected from the goal back to the start
+			for i, j := 0, len(res.Path)-1; i < j; i, j = i+1, j-1 {
+				res.Path[i], res.Path[j] = res.Path[j], res.Path[i]
+			}
+			return res, nil
+		}
+		return res, &search.Error{Reason: reason, Err: err, Stats: res.Stats}
+	}
+	// The function given to Expand is made once, cur is the index of the node being expanded
+	cur := 0
+	yield := func(st S) bool {
+		stats.Generated++
+		key := sp.Key(st)
+		if _, ok := closed[key]; ok {
+			stats.Duplicates++
+			return true
+		}
+		add(st, key, cur)
+		return true
+	}
+	for open.size() > 0 {
+		if stats.Expanded%search.CheckEvery == 0 {
+			select {
+			case <-ctx.Done():
+				return finish(-1, search.Cancelled, ctx.Err())
+			default:
+			}
+		}
+		if open.size() > stats.PeakFrontier {
+			stats.PeakFrontier = open.size()
+		}
+		cur = open.pop()
+		n := nodes.at(cur)
+		if _, ok := closed[n.key]; ok {
+			// A duplicate that was on the frontier more than once
+			stats.Duplicates++
+			stats.Memory -= search.StateSize
+			continue
+		}
+		if limits.MaxExpansions > 0 && stats.Expanded >= limits.MaxExpansions {
+			return finish(-1, search.ExpansionsExceeded, nil)
+		}
+		closed[n.key] = struct{}{}
+		stats.Expanded++
+		if sp.Done(n.state) {
+			return finish(cur, search.NoSolution, nil)
+		}
+		sp.Expand(n.state, yield)
+		if limits.MaxMemory > 0 && stats.Memory > limits.MaxMemory {
+			return finish(-1, search.MemoryExceeded, nil)
+		}
+	}
+	return finish(-1, search.NoSolution, nil)
+}
+
+// DepthFirstContext is DepthFirstContext of the search package for the state space
+// If there is no goal the error is a *search.Error saying why, the result is returned either way
+// The limits are those of the search package but the Observer is not used (its events have SearchF states) and
+// MaxMemory counts StateSize bytes for every state
+func (sp *Search[S, K]) DepthFirstContext(ctx context.Context, start S, limits search.Limits) (*Result[S], error) {
+	return sp.run(ctx, start, limits, &stack{}, &arena[S, K]{}, nil)
+}
+
+// BreadthFirstContext is BreadthFirstContext of the search package for the state space
+func (sp *Search[S, K]) BreadthFirstContext(ctx context.Context, start S, limits search.Limits) (*Result[S], error) {
+	return sp.run(ctx, start, limits, &queue{}, &arena[S, K]{}, nil)
+}
+
+// BestCostContext is BestCostContext of the search package (the lowest Cost first) for the state space
+func (sp *Search[S, K]) BestCostContext(ctx context.Context, start S, limits search.Limits) (*Result[S], error) {
+	nodes := &arena[S, K]{}
+	return sp.run(ctx, start, limits, &priority[S, K]{nodes: nodes}, nodes, sp.Cost)
+}
+
+// BestCostAwayContext is BestCostAwayContext of the search package (the lowest Cost plus Away first, A*) for the
+// state space
+func (sp *Search[S, K]) BestCostAwayContext(ctx context.Context, start S, limits search.Limits) (*Result[S], error) {
+	nodes := &arena[S, K]{}
+	return sp.run(ctx, start, limits, &priority[S, K]{nodes: nodes}, nodes, func(st S) float64 { return sp.Cost(st) + sp.Away(st) })
+}
+
+// DepthFirst does depth first search
+func (sp *Search[S, K]) DepthFirst(start S) *Result[S] {
+	res, _ := sp.DepthFirstContext(context.Background(), start, search.Limits{})
+	return res
+}
+
+// BreadthFirst does breadth first search
+func (sp *Search[S, K]) BreadthFirst(start S) *Result[S] {
+	res, _ := sp.BreadthFirstContext(context.Background(), start, search.Limits{})
+	return res
+}
+
+// BestCost does best first search on the lowest Cost
+func (sp *Search[S, K]) BestCost(start S) *Result[S] {
+	res, _ := sp.BestCostContext(context.Background(), start, search.Limits{})
+	return res
+}
+
+// BestCostAway does A*
+func (sp *Search[S, K]) BestCostAway(start S) *Result[S] {
+	res, _ := sp.BestCostAwayContext(context.Background(), start, search.Limits{})
+	return res
+}
@@ -0,0 +1,169 @@
+// typed_test.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Checks the typed searches against those of the search package (with the loop of the Context searches, which the
+// typed ones follow) and compares their allocations
+// Run the benchmarks with go test -bench . ./typed
+package typed
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/hduplooy/gosearch-test/queens"
+	"github.com/hduplooy/gosearch-test/roads"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// The size of the queens board and the rows (and columns) of the road network searched
+const (
+	benchQueens = 20
+	benchRows   = 50
+)
+
+// roadsStart is the drive across the network, it is made once so that the benchmarks do not measure making it
+var roadsStart *roads.Drive
+
+// startDrive returns the drive across a grid network of benchRows by benchRows cities
+func startDrive(tb testing.TB) *roads.Drive {
+	tb.Helper()
+	if roadsStart == nil {
+		net := roads.Grid(benchRows, benchRows, 1)
+		start, err := net.StartDrive("R0C0", fmt.Sprintf("R%dC%d", benchRows-1, benchRows-1))
+		if err != nil {
+			tb.Fatal(err)
+		}
+		roadsStart = start
+	}
+	return roadsStart
+}
+
+// The problems searched three ways: through SearchF, with Adapt and with the typed search written for it
+var problems = []struct {
+	name    string
+	searchf func(tb testing.TB) Outcome
+	adapt   func(tb testing.TB) Outcome
+	typed   func(tb testing.TB) Outcome
+}{
+	{
+		"queens",
+		func(tb testing.TB) Outcome {
+			res, _ := search.DepthFirstContext(context.Background(), queens.NewBitBoard(benchQueens), search.Limits{})
+			return OutcomeOf(res)
+		},
+		func(tb testing.TB) Outcome {
+			res := Adapt[queens.BitBoard]().DepthFirst(queens.NewBitBoard(benchQueens))
+			return res.Outcome()
+		},
+		func(tb testing.TB) Outcome {
+			res := Queens.DepthFirst(queens.NewBitBoard(benchQueens))
+			return res.Outcome()
+		},
+	},
+	{
+		"roads",
+		func(tb testing.TB) Outcome {
+			res, _ := search.BestCostAwayContext(context.Background(), startDrive(tb), search.Limits{})
+			return OutcomeOf(res)
+		},
+		func(tb testing.TB) Outcome {
+			res := Adapt[*roads.Drive]().BestCostAway(startDrive(tb))
+			return res.Outcome()
+		},
+		func(tb testing.TB) Outcome {
+			start := startDrive(tb)
+			res := Drives(start.Destination).BestCostAway(Drive{City: start.City})
+			return res.Outcome()
+		},
+	},
+}
+
+func TestSameAsSearchF(t *testing.T) {
+	for _, prob := range problems {
+		want := prob.searchf(t)
+		if !want.Found {
+			t.Fatalf("%s: no goal found through SearchF", prob.name)
+		}
+		for name, got := range map[string]Outcome{"adapt": prob.adapt(t), "typed": prob.typed(t)} {
+			if !got.Same(want) {
+				t.Errorf("%s with %s: found %v cost %g in %d steps, through SearchF %v cost %g in %d steps",
+					prob.name, name, got.Found, got.Cost, got.Expanded, want.Found, want.Cost, want.Expanded)
+			}
+		}
+	}
+}
+
+func TestPriority(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	nodes := &arena[int, int]{}
+	p := &priority[int, int]{nodes: nodes}
+	// want is what is on the heap, kept in the order the heap must give it
+	var want []int
+	order := func() {
+		sort.Slice(want, func(i, j int) bool {
+			a, b := nodes.at(want[i]), nodes.at(want[j])
+			if a.prio != b.prio {
+				return a.prio < b.prio
+			}
+			return want[i] < want[j]
+		})
+	}
+	// Push and pop at random with few different priorities so that there are many ties
+	for step := 0; step < 5000; step++ {
+		if p.size() != len(want) {
+			t.Fatalf("step %d: size %d, expected %d", step, p.size(), len(want))
+		}
+		if len(want) > 0 && rnd.Intn(3) == 0 {
+			order()
+			if got := p.pop(); got != want[0] {
+				t.Fatalf("step %d: popped %d (prio %g), expected %d (prio %g)", step, got, nodes.at(got).prio, want[0], nodes.at(want[0]).prio)
+			}
+			want = want[1:]
+			continue
+		}
+		i := nodes.add(node[int, int]{prio: float64(rnd.Intn(20))})
+		p.push(i)
+		want = append(want, i)
+	}
+	order()
+	for _, val := range want {
+		if got := p.pop(); got != val {
+			t.Fatalf("popped %d, expected %d", got, val)
+		}
+	}
+	if p.size() != 0 {
+		t.Fatalf("%d items left", p.size())
+	}
+}
+
+// bench runs the search of every problem given by fn
+func bench(b *testing.B, fn func(prob int) func(testing.TB) Outcome) {
+	for i, prob := range problems {
+		b.Run(prob.name, func(b *testing.B) {
+			run := fn(i)
+			run(b)
+			b.ReportAllocs()
+			b.ResetTimer()
+			steps := 0
+			for j := 0; j < b.N; j++ {
+				steps += run(b).Expanded
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(steps), "ns/step")
+		})
+	}
+}
+
+func BenchmarkSearchF(b *testing.B) {
+	bench(b, func(i int) func(testing.TB) Outcome { return problems[i].searchf })
+}
+
+func BenchmarkAdapt(b *testing.B) {
+	bench(b, func(i int) func(testing.TB) Outcome { return problems[i].adapt })
+}
+
+func BenchmarkTyped(b *testing.B) {
+	bench(b, func(i int) func(testing.TB) Outcome { return problems[i].typed })
+}
@@ -0,0 +1,84 @@
+// waterjugs.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Implements breadth first search on the water jugs puzzle of the puzzles package, with BreadthFirstContext of the
+// search package so that a state of the jugs reached again is not searched again
+// With only unmarked jugs, a tap and a drain, measure out the target amount in one of the jugs
+// With -goal every jug must end up with the given level and with -notap water can only be poured between the jugs
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hduplooy/gosearch-test/puzzles"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// ints parses numbers separated by commas, an empty string gives nil
+func ints(text string) ([]int, error) {
+	if text == "" {
+		return nil, nil
+	}
+	fields := strings.Split(text, ",")
+	tmp := make([]int, len(fields))
+	for i, val := range fields {
+		var err error
+		if tmp[i], err = strconv.Atoi(strings.TrimSpace(val)); err != nil {
+			return nil, err
+		}
+	}
+	return tmp, nil
+}
+
+// solve sets up the puzzle and searches for the shortest solution
+// It returns the result of the search and the solution (nil if there is none)
+func solve(caps, levels string, target int, goal string, notap bool) (*search.Result, []puzzles.Step, error) {
+	jugs := &puzzles.Jugs{Target: target, NoTap: notap}
+	var err error
+	if jugs.Caps, err = ints(caps); err != nil {
+		return nil, nil, err
+	}
+	if jugs.Goal, err = ints(goal); err != nil {
+		return nil, nil, err
+	}
+	lvls, err := ints(levels)
+	if err != nil {
+		return nil, nil, err
+	}
+	start, err := jugs.Start(lvls)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, _ := search.BreadthFirstContext(context.Background(), start, search.Limits{})
+	if res.Goal == nil {
+		return res, nil, nil
+	}
+	return res, puzzles.Solution(res.History(), res.Goal), nil
+}
+
+func main() {
+	caps := flag.String("caps", "3,5", "capacities of the jugs separated by commas")
+	levels := flag.String("levels", "", "starting levels of the jugs (all empty by default)")
+	target := flag.Int("target", 4, "amount to measure in any of the jugs")
+	goal := flag.String("goal", "", "levels all the jugs must end up with instead of -target")
+	notap := flag.Bool("notap", false, "the jugs can not be filled or emptied, only poured into each other")
+	flag.Parse()
+
+	res, steps, err := solve(*caps, *levels, *target, *goal, *notap)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid puzzle:", err)
+		os.Exit(2)
+	}
+	fmt.Println(res)
+	if steps == nil {
+		fmt.Println("No solution exists")
+		os.Exit(1)
+	}
+	fmt.Printf("Solved in %d moves\n", len(steps)-1)
+	fmt.Print(puzzles.Print(steps))
+}
@@ -1,193 +1,72 @@
 // webcitysearch.go
 // Author: Hannes du Plooy
-// Revision Date: 3 Sep 2016
+// Revision Date: 19 Oct 2026
 // Implements BestCostAwaySearch of hduplooy/gosearch to search for a road trip from one city to another
 // It is similar to citysearchcost.go except we keep track of how far we travelled and how far away we are from the goal
 // This is the same as citysearchcostaway.go except that a web server is providing a web page frontend
+// The search uses the context of the request so it stops when the browser goes away, and it is also stopped
+// after -timeout, -expansions steps or when it uses more than -memory bytes
+// The /animate page draws the network of the roads package and replays a search step by step
+// The /anytime page draws the shorter and shorter routes found by anytime weighted A* as they are sent by the server
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"html"
 	"math"
 	"net/http"
-	"sort"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	src "github.com/hduplooy/gosearch"
+	"github.com/hduplooy/gosearch-test/roads"
+	"github.com/hduplooy/gosearch-test/search"
 )
 
-// City just keeps the city information in this implementation
-// Name is the name of the city
-// Neighbours are all the cities that can be reached directly
-// Distances are the distances in km from this city to its neighbours
-// Key Just to not use a string to search for we make the key the number of when it was created (so it is unique)
-// Latitude and Longitude is the actual geo coordinates of the cities
-// Away is the direct distance from this city to the goal
-type City struct {
-	Name       string
-	Neighbours []*City
-	Distances  []float64
-	Latitude   float64
-	Longitude  float64
-	Key        int
-	Away       float64
-}
-
-// This is the actual state (because we can actually go to the same city again, if we really want to)
-// Includes City
-// HistKey is a concatenation of the Key values of all the cities visited so far, so this is unique for each state
-// TotCost is the total distance travelled so far
-// Destination is the goal state
-type CitySE struct {
-	*City
-	HistKey     string
-	TotCost     float64
-	Destination *City
-}
-
-// Descendants get all the neighbours of a city
-// A neighbour is only valid if it has not been visited before (it's Key does not appear in the HistKey)
-func (city *CitySE) Descendants() []src.SearchF {
-	tmp := make([]src.SearchF, 0, len(city.Neighbours))
-	for i, val := range city.Neighbours {
-		k := strconv.Itoa(val.Key)
-		if strings.Index(city.HistKey, k) >= 0 {
-			continue
-		}
-		tmp = append(tmp, &CitySE{val, city.HistKey + "-" + k, city.TotCost + city.Distances[i], city.Destination})
-	}
-	return tmp
-}
-
-// Done is true when the current state is the same as the destination
-func (city *CitySE) Done() bool {
-	return city.Name == city.Destination.Name
-}
-
-// Cost returns the total distance travelled so far
-func (city *CitySE) Cost() float64 { return city.TotCost }
-
-// Away returns the geo distance from the current city to the destination
-func (city *CitySE) Away() float64 {
-	return city.Distance(city.Destination)
-}
-
-// Key just returns the HistKey (cities visited so far)
-func (city *CitySE) Key() string {
-	return city.HistKey
-}
-
-// Stringer for CitySE - it returns the table entry representing the city name and distance travelled so far
-func (city *CitySE) String() string {
-	return "<tr class='res'><td class='res'>" + city.Name + "</td><td class='res' align='right'>" + fmt.Sprintf("%.2f", city.TotCost) + "km</td></tr>\n"
-}
+// The limits of every search and how long it may take, and the time between the routes sent to the /anytime page
+var (
+	limits  search.Limits
+	timeout time.Duration
+	pace    time.Duration
+)
 
 // Database of cities
-var cities = make(map[string]*City)
+var network = roads.SouthAfrica()
 
 // Sorted slice of city names used for selects on html page
-var citynames []string
+var citynames = network.Names()
 
-// AddRoad will add cities if not in database and to each others neighbours as well as distance to each other
-func AddRoad(city1, city2 string, dist float64) {
-	c1, ok := cities[city1]
-	if !ok {
-		c1 = &City{Name: city1, Key: len(cities)}
-		cities[city1] = c1
+// The searches that can be animated by name, in the order they are shown on the form
+var (
+	searchNames = []string{"breadth", "cost", "costaway", "depth"}
+	searches    = map[string]func(context.Context, src.SearchF, search.Limits) (*search.Result, error){
+		"depth":    search.DepthFirstContext,
+		"breadth":  search.BreadthFirstContext,
+		"cost":     search.BestCostContext,
+		"costaway": search.BestCostAwayContext,
 	}
-	c2, ok := cities[city2]
-	if !ok {
-		c2 = &City{Name: city2, Key: len(cities)}
-		cities[city2] = c2
-	}
-	c1.Neighbours = append(c1.Neighbours, c2)
-	c1.Distances = append(c1.Distances, dist)
-	c2.Neighbours = append(c2.Neighbours, c1)
-	c2.Distances = append(c2.Distances, dist)
-}
-
-// Set the geo coordinates for a city
-func SetCoords(city string, lat, long float64) {
-	c, ok := cities[city]
-	if ok {
-		c.Latitude = lat
-		c.Longitude = long
-	}
-}
-
-// toRad converts degree values to radians
-func toRad(val float64) float64 {
-	return val * math.Pi / 180.0
-}
-
-// Determine the distance between cities based on their latitude and longitudes
-func (city *CitySE) Distance(city2 *City) float64 {
-	dlat := toRad(city.Latitude - city2.Latitude)
-	dlon := toRad(city.Longitude - city2.Longitude)
-	lat1 := toRad(city.Latitude)
-	lat2 := toRad(city2.Latitude)
-	a1 := math.Sin(dlat / 2.0)
-	a2 := math.Sin(dlon / 2.0)
-	a := a1*a1 + a2*a2*math.Cos(lat1)*math.Cos(lat2)
-	return 6371.0 * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-}
-
-// Initialize database
-func init() {
-	AddRoad("Pretoria", "Midrand", 28)
-	AddRoad("Midrand", "Johannesburg", 25)
-	AddRoad("Pretoria", "Kempton", 54)
-	AddRoad("Johannesburg", "Kempton", 25)
-	AddRoad("Johannesburg", "Klerksdorp", 172)
-	AddRoad("Klerksdorp", "Potchefstroom", 47)
-	AddRoad("Potchefstroom", "Kimberley", 358)
-	AddRoad("Johannesburg", "Vanderbijl", 72)
-	AddRoad("Vanderbijl", "Sasolburg", 17)
-	AddRoad("Johannesburg", "Vereeniging", 63)
-	AddRoad("Vereeniging", "Sasolburg", 29)
-	AddRoad("Johannesburg", "Kroonstad", 190)
-	AddRoad("Sasolburg", "Kroonstad", 124)
-	AddRoad("Kroonstad", "Ventersburg", 52)
-	AddRoad("Ventersburg", "Bloemfontein", 159)
-	AddRoad("Bloemfontein", "Kimberley", 168)
-	AddRoad("Bloemfontein", "Beaufort West", 570)
-	AddRoad("Kimberley", "Beaufort West", 453)
-	AddRoad("Beaufort West", "Worcester", 356)
-	AddRoad("Worcester", "Cape Town", 111)
-	AddRoad("Beaufort West", "George", 241)
-	AddRoad("George", "Cape Town", 431)
-	SetCoords("Pretoria", -25.7313, 28.2184)
-	SetCoords("Midrand", -25.98953, 28.12843)
-	SetCoords("Bloemfontein", -29.1183, 26.2249)
-	SetCoords("Cape Town", -33.9249, 18.4241)
-	SetCoords("Johannesburg", -26.2041, 28.0473)
-	SetCoords("Kempton", -26.1, 28.233334)
-	SetCoords("Klerksdorp", -26.859823, 26.631750)
-	SetCoords("Potchefstroom", -26.71667, 27.1)
-	SetCoords("Kimberley", -28.741943, 24.771944)
-	SetCoords("Vanderbijl", -26.703421, 27.807695)
-	SetCoords("Vereeniging", -26.673611, 27.931944)
-	SetCoords("Sasolburg", -26.810190, 27.827724)
-	SetCoords("Kroonstad", -27.644606, 27.250900)
-	SetCoords("Ventersburg", -28.08561, 27.13814)
-	SetCoords("Beaufort West", -32.35671, 22.58295)
-	SetCoords("Worcester", -33.64651, 19.44852)
-	SetCoords("George", -33.963, 22.46173)
-	citynames = make([]string, 0, len(cities))
-	for _, val := range cities {
-		citynames = append(citynames, val.Name)
-	}
-	sort.Strings(citynames)
-}
-
-// Handle the only page in the web app
-func mainHandler(w http.ResponseWriter, r *http.Request) {
-	// Get the fromcity and tocity values (if they are provided)
-	fromcity := r.FormValue("fromcity")
-	tocity := r.FormValue("tocity")
+)
 
-	fmt.Fprintf(w, `<!DOCTYPE html>
+// The weights of Away that anytime weighted A* can start with
+var weights = []string{"1", "1.5", "2", "3", "5", "10"}
+
+// mostFrames is the most expansions that are recorded for an animation
+const mostFrames = 5000
+
+// The size of the map drawn and the margin around the cities
+const (
+	mapWidth  = 640
+	mapHeight = 560
+	mapMargin = 60
+)
+
+// The page header and style shared by the pages
+const pageHead = `<!DOCTYPE html>
 <html><head>
 <style>
 body { margin: 20px; }
@@ -196,54 +75,452 @@ body { margin: 20px; }
     border-collapse: collapse;
 }
 td { padding: 5px; }
+svg { border: 1px solid #ccc; background: #fafafa; }
+line { stroke: #bbb; stroke-width: 2; }
+line.path { stroke: #d33; stroke-width: 4; }
+line.old { stroke: #e9a; stroke-width: 3; stroke-dasharray: 6 4; }
+circle { fill: white; stroke: #555; stroke-width: 2; }
+circle.open { fill: #9cf; }
+circle.closed { fill: #aaa; }
+circle.cur { fill: #d33; }
+circle.goal { stroke: #2a2; stroke-width: 4; }
+text { font: 12px sans-serif; }
 </style>
 </head><body>
-<h1>Shortest Road</h1>
-<form action="/" method="post" id="theform">
-<table>`)
-	fmt.Fprintf(w, "<tr><td>From City</td><td><select id='fromcity' name='fromcity'>\n")
-	// Put the cities available as options in the select
-	for _, val := range citynames {
-		fmt.Fprintf(w, "<option")
-		if val == fromcity {
-			fmt.Fprintf(w, " selected")
-		}
-		fmt.Fprintf(w, ">%s</option>\n", val)
-	}
-	fmt.Fprintf(w, "</td></tr>\n")
-	fmt.Fprintf(w, "<tr><td>To City</td><td><select id='tocity' name='tocity'>\n")
-	// Put the cities available as options in the select
-	for _, val := range citynames {
+`
+
+// writeSelect writes a select with the options and the one selected
+func writeSelect(w http.ResponseWriter, label, name string, options []string, selected string) {
+	fmt.Fprintf(w, "<tr><td>%s</td><td><select id='%s' name='%s'>\n", label, name, name)
+	for _, val := range options {
 		fmt.Fprintf(w, "<option")
-		if val == tocity {
+		if val == selected {
 			fmt.Fprintf(w, " selected")
 		}
-		fmt.Fprintf(w, ">%s</option>\n", val)
+		fmt.Fprintf(w, ">%s</option>\n", html.EscapeString(val))
 	}
-	fmt.Fprintf(w, "</td></tr>\n")
+	fmt.Fprintf(w, "</select></td></tr>\n")
+}
+
+// Handle the main page of the web app
+func mainHandler(w http.ResponseWriter, r *http.Request) {
+	// Get the fromcity and tocity values (if they are provided)
+	fromcity := r.FormValue("fromcity")
+	tocity := r.FormValue("tocity")
+
+	fmt.Fprintf(w, "%s", pageHead)
+	fmt.Fprintf(w, `<h1>Shortest Road</h1>
+<p><a href="/animate">See how the searches differ</a> | <a href="/anytime">See the routes get shorter</a></p>
+<form action="/" method="post" id="theform">
+<table>`)
+	// Put the cities available as options in the selects
+	writeSelect(w, "From City", "fromcity", citynames, fromcity)
+	writeSelect(w, "To City", "tocity", citynames, tocity)
 	fmt.Fprintf(w, "<tr><td>&nbsp;</td><td><input type='submit' name='Submit' id='submit'></td></tr>\n")
 	fmt.Fprintf(w, "</table>\n")
 	fmt.Fprintf(w, "</form>\n")
 	// If fromcity and tocity is available it means that the form was submitted and we can use the values
 	if fromcity != "" && tocity != "" {
-		// Get the startcity from the database
-		city := cities[fromcity]
-		// Generate the initial state with the destination added then call the search routine
-		cnt, ans, hist := src.BestCostAwaySearch(&CitySE{city, strconv.Itoa(city.Key), 0, cities[tocity]}, true)
+		// Generate the initial state with the destination added
+		start, err := network.Start(fromcity, tocity)
+		if err != nil {
+			fmt.Fprintf(w, "<h3>%s</h3>\n</body></html>\n", html.EscapeString(err.Error()))
+			return
+		}
+		// The search stops when the request is cancelled or takes too long
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		res, err := search.BestCostAwayContext(ctx, start, limits)
+		if err != nil {
+			fmt.Fprintf(w, "<h3>No road found: %s</h3>\n", err)
+			fmt.Fprintf(w, "<p>%d steps, %d states generated, %d still on the frontier</p>\n", res.Expanded, res.Generated, res.Frontier)
+			fmt.Fprintf(w, "</body></html>\n")
+			return
+		}
 		// Output the results
-		fmt.Fprintf(w, "<h3>Found in %d steps</h3>\n", cnt)
+		fmt.Fprintf(w, "<h3>%s</h3>\n", res)
 		fmt.Fprintf(w, "<table class='res'>\n")
 		fmt.Fprintf(w, "<tr class='res'><th class='res'>City</th><th class='res'>Distance</th></tr>\n")
-		for i := len(hist) - 1; i >= 0; i-- {
-			fmt.Fprintf(w, "%s", hist[i])
+		for _, st := range res.Path {
+			trip := st.(*roads.Trip)
+			fmt.Fprintf(w, "<tr class='res'><td class='res'>%s</td><td class='res' align='right'>%.2fkm</td></tr>\n", trip.Name, trip.TotCost)
 		}
-		fmt.Fprintf(w, "%s", ans)
 		fmt.Fprintf(w, "</table>\n")
 	}
 	fmt.Fprintf(w, "</body></html>\n")
 }
 
+// frame is an expansion of the search that is replayed
+// City is the key of the city of the trip expanded and Path the keys of all the cities on the trip
+// G is the distance travelled and F that plus the direct distance to the destination
+// Added are the cities of the trips generated (and put on the frontier) and Dropped the cities of the trips taken
+// from the frontier and dropped as duplicates before this expansion
+type frame struct {
+	City    int     `json:"city"`
+	Path    []int   `json:"path"`
+	G       float64 `json:"g"`
+	F       float64 `json:"f"`
+	Added   []int   `json:"added"`
+	Dropped []int   `json:"dropped,omitempty"`
+}
+
+// cityKeys returns the keys of the cities on the route of a trip
+func cityKeys(trip *roads.Trip) []int {
+	tmp := make([]int, 0, 8)
+	for _, val := range strings.Split(strings.Trim(trip.Route, "-"), "-") {
+		key, _ := strconv.Atoi(val)
+		tmp = append(tmp, key)
+	}
+	return tmp
+}
+
+// recordFrames does the search and returns the frames to replay it
+// A generated trip that is dropped as a duplicate straight away is never on the frontier so it is taken off again,
+// it is told apart from a trip taken from the frontier by being the last one generated
+func recordFrames(ctx context.Context, find func(context.Context, src.SearchF, search.Limits) (*search.Result, error), start *roads.Trip) ([]frame, *search.Result, error) {
+	var frames []frame
+	var dropped []int
+	lastKey := ""
+	lim := limits
+	if lim.MaxExpansions == 0 || lim.MaxExpansions > mostFrames {
+		lim.MaxExpansions = mostFrames
+	}
+	lim.Observer = search.Hooks{
+		Expand: func(ev search.Event) {
+			trip := ev.State.(*roads.Trip)
+			frames = append(frames, frame{City: trip.City.Key, Path: cityKeys(trip), G: trip.TotCost, F: trip.TotCost + trip.Away(), Added: []int{}, Dropped: dropped})
+			dropped, lastKey = nil, ""
+		},
+		Generate: func(ev search.Event) {
+			fr := &frames[len(frames)-1]
+			fr.Added = append(fr.Added, ev.State.(*roads.Trip).City.Key)
+			lastKey = ev.Key
+		},
+		Duplicate: func(ev search.Event) {
+			if ev.Key == lastKey {
+				fr := &frames[len(frames)-1]
+				fr.Added = fr.Added[:len(fr.Added)-1]
+				lastKey = ""
+				return
+			}
+			dropped = append(dropped, ev.State.(*roads.Trip).City.Key)
+		},
+	}
+	res, err := find(ctx, start, lim)
+	return frames, res, err
+}
+
+// cityData is a city as it is drawn
+type cityData struct {
+	Name string  `json:"name"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+}
+
+// layout places the cities on the map, west to the left and north to the top
+// The longitudes are scaled by the cosine of the middle latitude so that the distances look right
+func layout() []cityData {
+	minLat, maxLat, minLon, maxLon := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	for _, c := range network.Cities {
+		minLat, maxLat = math.Min(minLat, c.Latitude), math.Max(maxLat, c.Latitude)
+		minLon, maxLon = math.Min(minLon, c.Longitude), math.Max(maxLon, c.Longitude)
+	}
+	scaleX := math.Cos((minLat + maxLat) / 2 * math.Pi / 180)
+	scale := math.Min((mapWidth-2*mapMargin)/((maxLon-minLon)*scaleX), (mapHeight-2*mapMargin)/(maxLat-minLat))
+	tmp := make([]cityData, len(network.Cities))
+	for _, c := range network.Cities {
+		tmp[c.Key] = cityData{c.Name, mapMargin + (c.Longitude-minLon)*scaleX*scale, mapMargin + (maxLat-c.Latitude)*scale}
+	}
+	return tmp
+}
+
+// writeMap writes the SVG with the roads and the cities, the elements have ids so that the script can change them
+func writeMap(w http.ResponseWriter, places []cityData, goal int) {
+	fmt.Fprintf(w, "<svg width='%d' height='%d'>\n", mapWidth, mapHeight)
+	for _, c := range network.Cities {
+		for i, val := range c.Neighbours {
+			if c.Key < val.Key {
+				p1, p2 := places[c.Key], places[val.Key]
+				fmt.Fprintf(w, "<line id='r%d-%d' x1='%.1f' y1='%.1f' x2='%.1f' y2='%.1f'><title>%.0fkm</title></line>\n", c.Key, val.Key, p1.X, p1.Y, p2.X, p2.Y, c.Distances[i])
+			}
+		}
+	}
+	for key, p := range places {
+		class := ""
+		if key == goal {
+			class = " class='goal'"
+		}
+		fmt.Fprintf(w, "<circle id='c%d'%s cx='%.1f' cy='%.1f' r='9'></circle>\n", key, class, p.X, p.Y)
+		fmt.Fprintf(w, "<text x='%.1f' y='%.1f'>%s <tspan id='n%d'></tspan></text>\n", p.X+12, p.Y+4, html.EscapeString(p.Name), key)
+	}
+	fmt.Fprintf(w, "</svg>\n")
+}
+
+// The script replaying the frames
+// A city is red when a trip ending there is expanded, blue when there are trips ending there on the frontier
+// (with their number next to the name) and grey when trips ending there have been expanded before
+// The roads of the trip being expanded are red
+const animateScript = `<script>
+var pos = 0, timer = null, open = [], closed = [];
+function reset() {
+	pause();
+	pos = 0;
+	open = cities.map(function() { return 0; });
+	closed = cities.map(function() { return false; });
+	open[frames.length > 0 ? frames[0].city : 0] = 1;
+	draw(null);
+}
+function draw(fr) {
+	for (var i = 0; i < cities.length; i++) {
+		var cls = "";
+		if (fr && fr.city == i) cls = "cur";
+		else if (open[i] > 0) cls = "open";
+		else if (closed[i]) cls = "closed";
+		var c = document.getElementById("c" + i);
+		c.classList.remove("cur", "open", "closed");
+		if (cls) c.classList.add(cls);
+		document.getElementById("n" + i).textContent = open[i] > 0 ? "(" + open[i] + ")" : "";
+	}
+	var lines = document.getElementsByTagName("line");
+	for (var i = 0; i < lines.length; i++) lines[i].classList.remove("path");
+	var text = "Step 0 of " + frames.length;
+	if (fr) {
+		for (var i = 1; i < fr.path.length; i++) {
+			var a = Math.min(fr.path[i-1], fr.path[i]), b = Math.max(fr.path[i-1], fr.path[i]);
+			document.getElementById("r" + a + "-" + b).classList.add("path");
+		}
+		var front = open.reduce(function(s, v) { return s + v; }, 0);
+		text = "Step " + pos + " of " + frames.length + ": " + fr.path.map(function(k) { return cities[k].name; }).join(" - ") +
+			" (g " + fr.g.toFixed(0) + "km, f " + fr.f.toFixed(0) + "km), " + front + " trips on the frontier";
+		if (pos == frames.length) text += ". " + done;
+	}
+	document.getElementById("status").textContent = text;
+}
+function step() {
+	if (pos >= frames.length) {
+		pause();
+		return;
+	}
+	var fr = frames[pos++];
+	(fr.dropped || []).forEach(function(k) { open[k]--; });
+	open[fr.city]--;
+	closed[fr.city] = true;
+	fr.added.forEach(function(k) { open[k]++; });
+	draw(fr);
+}
+function play() {
+	if (timer == null) timer = setInterval(step, document.getElementById("speed").value);
+}
+function pause() {
+	if (timer != null) clearInterval(timer);
+	timer = null;
+}
+reset();
+</script>
+`
+
+// Handle the animation page, it replays the search chosen on the map
+func animateHandler(w http.ResponseWriter, r *http.Request) {
+	fromcity := r.FormValue("fromcity")
+	tocity := r.FormValue("tocity")
+	algo := r.FormValue("search")
+	if fromcity == "" || tocity == "" {
+		fromcity, tocity = "Johannesburg", "Cape Town"
+	}
+	if _, ok := searches[algo]; !ok {
+		algo = "costaway"
+	}
+	start, err := network.Start(fromcity, tocity)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	frames, res, err := recordFrames(ctx, searches[algo], start)
+	done := res.String()
+	if err != nil {
+		done = err.Error()
+	}
+
+	fmt.Fprintf(w, "%s", pageHead)
+	fmt.Fprintf(w, `<h1>How the searches differ</h1>
+<p><a href="/">Shortest Road</a></p>
+<form action="/animate" method="post">
+<table>`)
+	writeSelect(w, "From City", "fromcity", citynames, fromcity)
+	writeSelect(w, "To City", "tocity", citynames, tocity)
+	writeSelect(w, "Search", "search", searchNames, algo)
+	fmt.Fprintf(w, "<tr><td>&nbsp;</td><td><input type='submit' value='Search'></td></tr>\n")
+	fmt.Fprintf(w, "</table>\n</form>\n")
+	fmt.Fprintf(w, `<p><button onclick="play()">Play</button> <button onclick="pause()">Pause</button>
+<button onclick="pause(); step()">Step</button> <button onclick="reset()">Reset</button>
+Delay <select id="speed" onchange="if (timer != null) { pause(); play(); }"><option value="100">0.1s</option><option value="400" selected>0.4s</option><option value="1000">1s</option></select></p>
+<p id="status"></p>
+`)
+	places := layout()
+	writeMap(w, places, start.Destination.Key)
+	data, _ := json.Marshal(places)
+	fmt.Fprintf(w, "<script>\nvar cities = %s;\n", data)
+	data, _ = json.Marshal(frames)
+	fmt.Fprintf(w, "var frames = %s;\n", data)
+	data, _ = json.Marshal(done)
+	fmt.Fprintf(w, "var done = %s;\n</script>\n", data)
+	fmt.Fprintf(w, "%s</body></html>\n", animateScript)
+}
+
+// improvement is a route found by anytime weighted A* as it is sent to the /anytime page
+// Path has the keys of the cities on the route, Bound how many times the shortest it can be at most
+type improvement struct {
+	Path  []int   `json:"path"`
+	Cost  float64 `json:"cost"`
+	Bound float64 `json:"bound"`
+	Steps int     `json:"steps"`
+	Time  string  `json:"time"`
+}
+
+// Handle the stream of the /anytime page
+// Every route found is sent as a server sent event as soon as it is found (but at least pace apart), a done event
+// is sent at the end with why the search stopped
+func anytimeStreamHandler(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	start, err := network.Start(r.FormValue("fromcity"), r.FormValue("tocity"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	weight, err := strconv.ParseFloat(r.FormValue("weight"), 64)
+	if err != nil || weight < 1 {
+		weight = 2
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	var last *search.Result
+	results, errc := search.Anytime(ctx, start, weight, limits)
+	for res := range results {
+		if last != nil {
+			select {
+			case <-time.After(pace):
+			case <-ctx.Done():
+			}
+		}
+		last = res
+		data, _ := json.Marshal(improvement{cityKeys(res.Goal.(*roads.Trip)), res.Cost, res.Bound, res.Expanded, res.Time.String()})
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+	}
+	done := "No road found"
+	switch err := <-errc; {
+	case ctx.Err() != nil:
+		done = "Stopped: " + ctx.Err().Error()
+	case last != nil && err == nil:
+		done = fmt.Sprintf("The shortest road is %.2fkm", last.Cost)
+	case last != nil:
+		done = fmt.Sprintf("Stopped by the limits, the shortest road is at least %.2fkm", last.Cost/last.Bound)
+	}
+	data, _ := json.Marshal(done)
+	fmt.Fprintf(w, "event: done\ndata: %s\n\n", data)
+	flusher.Flush()
+}
+
+// The script of the /anytime page, it draws every route on the map as it arrives and adds it to the table
+// The newest route is red and the routes before it are dashed
+const anytimeScript = `<script>
+var source = new EventSource(stream), found = 0;
+function road(a, b) {
+	return document.getElementById("r" + Math.min(a, b) + "-" + Math.max(a, b));
+}
+source.onmessage = function(ev) {
+	var imp = JSON.parse(ev.data);
+	var lines = document.querySelectorAll("line.path");
+	for (var i = 0; i < lines.length; i++) {
+		lines[i].classList.remove("path");
+		lines[i].classList.add("old");
+	}
+	for (var i = 1; i < imp.path.length; i++) {
+		var line = road(imp.path[i-1], imp.path[i]);
+		line.classList.remove("old");
+		line.classList.add("path");
+	}
+	found++;
+	var row = document.getElementById("routes").insertRow(-1);
+	[found, imp.cost.toFixed(2) + "km", imp.bound.toFixed(3), imp.steps, imp.time,
+		imp.path.map(function(k) { return cities[k].name; }).join(" - ")].forEach(function(val) {
+		row.insertCell(-1).textContent = val;
+	});
+	document.getElementById("status").textContent = "Route " + found + " is at most " + imp.bound.toFixed(3) + " times the shortest";
+};
+source.addEventListener("done", function(ev) {
+	source.close();
+	document.getElementById("status").textContent = JSON.parse(ev.data);
+});
+source.onerror = function() {
+	source.close();
+	document.getElementById("status").textContent = "The search was broken off";
+};
+</script>
+`
+
+// Handle the anytime page, it shows the routes found by anytime weighted A* getting shorter
+// The page opens the stream of /anytime/stream with the same form values
+func anytimeHandler(w http.ResponseWriter, r *http.Request) {
+	fromcity := r.FormValue("fromcity")
+	tocity := r.FormValue("tocity")
+	weight := r.FormValue("weight")
+	if fromcity == "" || tocity == "" {
+		fromcity, tocity = "Cape Town", "Potchefstroom"
+	}
+	if weight == "" {
+		weight = "5"
+	}
+	start, err := network.Start(fromcity, tocity)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fmt.Fprintf(w, "%s", pageHead)
+	fmt.Fprintf(w, `<h1>Shorter and shorter roads</h1>
+<p><a href="/">Shortest Road</a></p>
+<p>Anytime weighted A* first finds a road quickly by counting the distance left as the crow flies <i>weight</i> times,
+and then keeps on looking for shorter roads until it has proven that the last one is the shortest.</p>
+<form action="/anytime" method="post">
+<table>`)
+	writeSelect(w, "From City", "fromcity", citynames, fromcity)
+	writeSelect(w, "To City", "tocity", citynames, tocity)
+	writeSelect(w, "Weight", "weight", weights, weight)
+	fmt.Fprintf(w, "<tr><td>&nbsp;</td><td><input type='submit' value='Search'></td></tr>\n")
+	fmt.Fprintf(w, "</table>\n</form>\n")
+	fmt.Fprintf(w, "<p id='status'>Searching</p>\n")
+	places := layout()
+	writeMap(w, places, start.Destination.Key)
+	fmt.Fprintf(w, "<table class='res' id='routes'>\n")
+	fmt.Fprintf(w, "<tr><th>#</th><th>Distance</th><th>Bound</th><th>Steps</th><th>Time</th><th>Route</th></tr>\n</table>\n")
+	data, _ := json.Marshal(places)
+	fmt.Fprintf(w, "<script>\nvar cities = %s;\n", data)
+	query := url.Values{"fromcity": {fromcity}, "tocity": {tocity}, "weight": {weight}}
+	data, _ = json.Marshal("/anytime/stream?" + query.Encode())
+	fmt.Fprintf(w, "var stream = %s;\n</script>\n", data)
+	fmt.Fprintf(w, "%s</body></html>\n", anytimeScript)
+}
+
 func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.DurationVar(&timeout, "timeout", 10*time.Second, "longest a search may take")
+	flag.IntVar(&limits.MaxExpansions, "expansions", 1000000, "most steps a search may take (0 for no limit)")
+	flag.Int64Var(&limits.MaxMemory, "memory", 256<<20, "most bytes the states of a search may use (0 for no limit)")
+	flag.DurationVar(&pace, "pace", 700*time.Millisecond, "least time between the routes sent to the /anytime page")
+	flag.Parse()
+
 	http.HandleFunc("/", mainHandler)
-	http.ListenAndServe(":8080", nil)
+	http.HandleFunc("/animate", animateHandler)
+	http.HandleFunc("/anytime", anytimeHandler)
+	http.HandleFunc("/anytime/stream", anytimeStreamHandler)
+	http.ListenAndServe(*addr, nil)
 }
@@ -0,0 +1,78 @@
+// wolfgoat.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Implements breadth first search on the wolf, goat and cabbage puzzle of the puzzles package
+// BreadthFirstContext of the search package is used as it drops the banks already searched
+// The farmer must get everything across the river without anything being eaten while he is on the other bank
+// Other items (-items), what eats what (-eats) and the size of the boat (-boat) can be given
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hduplooy/gosearch-test/puzzles"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// setup returns the start state for the items separated by commas and the pairs item>eaten separated by commas
+func setup(items, eats string, boat int) (*puzzles.Crossing, error) {
+	names := strings.Split(items, ",")
+	index := make(map[string]int)
+	for i, val := range names {
+		names[i] = strings.TrimSpace(val)
+		index[names[i]] = i
+	}
+	pairs := make([][2]int, 0)
+	if eats != "" {
+		for _, val := range strings.Split(eats, ",") {
+			parts := strings.Split(val, ">")
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid pair %q, it must be item>eaten", val)
+			}
+			a, ok1 := index[strings.TrimSpace(parts[0])]
+			b, ok2 := index[strings.TrimSpace(parts[1])]
+			if !ok1 || !ok2 {
+				return nil, fmt.Errorf("unknown item in %q", val)
+			}
+			pairs = append(pairs, [2]int{a, b})
+		}
+	}
+	return puzzles.NewFarmer(names, pairs, boat)
+}
+
+// solve searches for the shortest solution and returns the number of steps and the solution (nil if there is none)
+func solve(items, eats string, boat int) (*search.Result, []puzzles.Step, error) {
+	start, err := setup(items, eats, boat)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, _ := search.BreadthFirstContext(context.Background(), start, search.Limits{})
+	if res.Goal == nil {
+		return res, nil, nil
+	}
+	return res, puzzles.Solution(res.History(), res.Goal), nil
+}
+
+func main() {
+	items := flag.String("items", "wolf,goat,cabbage", "items to take across separated by commas")
+	eats := flag.String("eats", "wolf>goat,goat>cabbage", "what eats what when the farmer is away, as item>eaten separated by commas")
+	boat := flag.Int("boat", 1, "number of items the farmer can take with him")
+	flag.Parse()
+
+	res, steps, err := solve(*items, *eats, *boat)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid puzzle:", err)
+		os.Exit(2)
+	}
+	fmt.Println(res)
+	if steps == nil {
+		fmt.Println("No solution exists")
+		os.Exit(1)
+	}
+	fmt.Printf("Solved in %d crossings\n", len(steps)-1)
+	fmt.Print(puzzles.Print(steps))
+}
@@ -0,0 +1,79 @@
+// wordladder.go
+// Author: Hannes du Plooy
+// Revision Date: 19 Oct 2026
+// Finds the shortest word ladder with breadth first search, or A* with -astar (BreadthFirstContext and
+// BestCostAwayContext of the search package, which do not search a word reached again)
+// Every step changes one letter and must be a word in the dictionary, with -edits a letter may also be inserted or deleted
+// For A* Away is the number of letters that differ from the goal (the edit distance with -edits)
+// The dictionary (-dict) has one word per line, without it a small list of common 3 and 4 letter words is used
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hduplooy/gosearch-test/ladder"
+	"github.com/hduplooy/gosearch-test/search"
+)
+
+// loadDictionary loads the dictionary from a file or uses the default words if name is empty
+func loadDictionary(name string, edits bool) (*ladder.Dictionary, error) {
+	if name == "" {
+		return ladder.NewDictionary(strings.Fields(ladder.CommonWords), edits), nil
+	}
+	fl, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fl.Close()
+	return ladder.Load(fl, edits)
+}
+
+// solve searches for the shortest ladder and returns the result of the search and the ladder (nil if there is none)
+func solve(dict *ladder.Dictionary, from, to string, astar bool) (*search.Result, []string, error) {
+	start, err := dict.Start(from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	find := search.BreadthFirstContext
+	if astar {
+		find = search.BestCostAwayContext
+	}
+	res, _ := find(context.Background(), start, search.Limits{})
+	if res.Goal == nil {
+		return res, nil, nil
+	}
+	return res, ladder.Ladder(res.History(), res.Goal), nil
+}
+
+func main() {
+	name := flag.String("dict", "", "dictionary file with one word per line")
+	from := flag.String("from", "cold", "word to start from")
+	to := flag.String("to", "warm", "word to end with")
+	edits := flag.Bool("edits", false, "a step may also insert or delete a letter")
+	astar := flag.Bool("astar", false, "use A* with the mismatch count instead of breadth first search")
+	flag.Parse()
+
+	tm := time.Now()
+	dict, err := loadDictionary(*name, *edits)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid dictionary:", err)
+		os.Exit(2)
+	}
+	fmt.Printf("Loaded %d words in %v\n", len(dict.Words), time.Since(tm))
+	res, words, err := solve(dict, *from, *to, *astar)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	fmt.Println(res)
+	if words == nil {
+		fmt.Printf("No ladder exists from %s to %s\n", *from, *to)
+		os.Exit(1)
+	}
+	fmt.Printf("Ladder of %d steps: %s\n", len(words)-1, strings.Join(words, " "))
+}